@@ -3,6 +3,7 @@ package ibkr
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"strconv"
 	"strings"
@@ -23,6 +24,12 @@ import (
 
 // -----------------------------------------------------------------------------
 
+// defaultMaxTickByTickSubscriptions is the minimum number of simultaneous tick-by-tick subscriptions allowed by the
+// server to any account.
+const defaultMaxTickByTickSubscriptions = 3
+
+// -----------------------------------------------------------------------------
+
 type Client struct {
 	rp            rundownprotection.RundownProtection
 	eventsHandler Events
@@ -40,6 +47,15 @@ type Client struct {
 
 	nextValidReqID        int32
 	nextValidReqWithoutID int32
+	orderIDs              orderIDAllocator
+	autoOpenOrdersActive  int32
+	accountUpdatesActive  int32
+	positionsActive       int32
+	newsBulletinsActive   int32
+	tickByTickLimit       int32
+	tickByTickCount       int32
+	execTracker           executionTracker
+	marketRules           marketRulesCache
 
 	reqMgr RequestManager
 }
@@ -52,6 +68,17 @@ type Options struct {
 	ConnectOptions       string
 	OptionalCapabilities string
 	ClientID             int32
+
+	// UseClientIDZero connects as client ID 0, the only client that can bind the orders entered in TWS. When set,
+	// ClientID must be zero.
+	UseClientIDZero bool
+
+	// OrderIDStore is an optional hook used to persist the last order ID handed out.
+	OrderIDStore OrderIDStore
+
+	// MaxTickByTickSubscriptions is the number of simultaneous tick-by-tick subscriptions allowed to the account. It
+	// depends on the market data lines of the account. Defaults to 3.
+	MaxTickByTickSubscriptions int32
 }
 
 // -----------------------------------------------------------------------------
@@ -92,6 +119,15 @@ func NewClient(ctx context.Context, opts Options) (*Client, error) {
 	if opts.ClientID < 0 {
 		return nil, errors.New("invalid client id")
 	}
+	if opts.UseClientIDZero && opts.ClientID != 0 {
+		return nil, errors.New("client id must be zero when using client id zero")
+	}
+	if opts.MaxTickByTickSubscriptions < 0 {
+		return nil, errors.New("invalid max tick-by-tick subscriptions")
+	}
+	if opts.MaxTickByTickSubscriptions == 0 {
+		opts.MaxTickByTickSubscriptions = defaultMaxTickByTickSubscriptions
+	}
 
 	// Create the client object
 	c := Client{
@@ -103,10 +139,19 @@ func NewClient(ctx context.Context, opts Options) (*Client, error) {
 
 		connMtx:          sync.Mutex{},
 		isDisconnectedEv: resetevent.NewManualResetEvent(),
+
+		tickByTickLimit: opts.MaxTickByTickSubscriptions,
 	}
 	c.rp.Initialize()
 	c.initRequestManager()
+	c.initExecutionTracker()
+	c.initMarketRulesCache()
+	atomic.StoreInt32(&c.nextValidReqID, firstRequestID)
 	atomic.StoreInt32(&c.nextValidReqWithoutID, 1)
+	err = c.initOrderIDAllocator(opts.OrderIDStore)
+	if err != nil {
+		return nil, err
+	}
 
 	// Try to connect to the server
 	err = c.connectToServer(ctx, opts)
@@ -253,23 +298,175 @@ func (c *Client) RequestManagedAccounts(ctx context.Context) ([]string, error) {
 	return resp.Accounts, nil
 }
 
+// RequestNextOrderID asks the server for the next valid order ID and returns the next one that will be used by
+// PlaceOrder.
+func (c *Client) RequestNextOrderID(ctx context.Context) (models.OrderID, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return 0, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.NextOrderIDResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_IDS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().
+		RawUInt32(common.REQ_IDS).
+		Int(VERSION).
+		Int(1) // Number of IDs, ignored by the server
+	if msgEnc.Err() != nil {
+		return 0, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return 0, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	// Done
+	return c.orderIDs.peek(), nil
+}
+
 // RequestHistoricalData retrieves historical market data.
 func (c *Client) RequestHistoricalData(ctx context.Context, opts models.HistoricalDataRequestOptions) (*models.HistoricalDataResponse, error) {
 	// Validate options
-	if opts.Contract == nil {
-		return nil, errors.New("invalid contract")
+	err := validateHistoricalDataRequestOptions(opts, false)
+	if err != nil {
+		return nil, err
 	}
-	if opts.Duration < 1 {
-		return nil, errors.New("invalid duration")
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
 	}
-	if len(opts.DurationUnit.String()) == 0 {
-		return nil, errors.New("invalid duration units")
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.HistoricalDataResponse{
+		Bars: make([]models.HistoricalDataBar, 0),
 	}
-	if opts.EndDate.IsZero() || opts.EndDate.Before(utils.EpochDate) {
-		return nil, errors.New("invalid end date")
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_HISTORICAL_DATA,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := c.buildHistoricalDataMessage(req.ID(), opts, false)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
 	}
-	if len(opts.BarSize.String()) == 0 {
-		return nil, errors.New("invalid bar size")
+
+	// Send it
+	err = c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// SubscribeHistoricalData retrieves historical market data up to now and keeps the last bar up to date. It returns
+// once the initial bars are received and, after them, the updates are sent to the channel. The end date of the
+// options must be zero.
+func (c *Client) SubscribeHistoricalData(ctx context.Context, opts models.HistoricalDataRequestOptions) (*models.HistoricalDataStreamResponse, error) {
+	// Validate options
+	err := validateHistoricalDataRequestOptions(opts, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.HistoricalDataStreamResponse{
+		Bars:    make([]models.HistoricalDataBar, 0),
+		Channel: make(chan models.HistoricalDataUpdate, 4),
+	}
+	stream := newHistoricalDataStream(resp)
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_HISTORICAL_DATA,
+		Response: stream,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelHistoricalData(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildHistoricalDataMessage(req.ID(), opts, true)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err = c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait until the initial bars are received
+	select {
+	case <-ctx.Done():
+		c.cancelHistoricalData(req)
+		return nil, ctx.Err()
+
+	case <-c.isDisconnectedEv.WaitCh():
+		c.cancelHistoricalData(req)
+		return nil, net.ErrClosed
+
+	case <-req.CompleteCh():
+		err = req.Err()
+		if err == nil {
+			err = net.ErrClosed
+		}
+		return nil, err
+
+	case <-stream.backfillDoneCh:
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestHeadTimestamp retrieves the timestamp of the earliest available historical data of the given contract.
+func (c *Client) RequestHeadTimestamp(ctx context.Context, opts models.HeadTimestampRequestOptions) (*models.HeadTimestampResponse, error) {
+	// Validate options
+	if opts.Contract == nil {
+		return nil, errors.New("invalid contract")
 	}
 	switch opts.WhatToShow {
 	case models.WhatToShowTrades:
@@ -277,14 +474,9 @@ func (c *Client) RequestHistoricalData(ctx context.Context, opts models.Historic
 	case models.WhatToShowBid:
 	case models.WhatToShowAsk:
 	case models.WhatToShowBidAsk:
-	case models.WhatToShowHistoricalVolatility:
-	case models.WhatToShowOptionImpliedVolatility:
 	default:
 		return nil, errors.New("invalid what to show")
 	}
-	if len(opts.WhatToShow.String()) == 0 {
-		return nil, errors.New("invalid what to show")
-	}
 
 	// Rundown protect
 	if !c.rp.Acquire() {
@@ -293,51 +485,34 @@ func (c *Client) RequestHistoricalData(ctx context.Context, opts models.Historic
 	defer c.rp.Release()
 
 	// Create the new request and response holder
-	resp := &models.HistoricalDataResponse{
-		Bars: make([]models.HistoricalDataBar, 0),
-	}
+	resp := &models.HeadTimestampResponse{}
 	req := c.createRequest(RequestOptions{
 		Type:     RequestTypeRequestWithID,
-		MsgCode:  common.REQ_HISTORICAL_DATA,
+		MsgCode:  common.REQ_HEAD_TIMESTAMP,
 		Response: resp,
 	})
 
 	// Build the message to send
 	var msgEnc *message.Encoder
-	if c.isProtoBufAvailable(common.REQ_HISTORICAL_DATA) {
-		pb := protobuf.HistoricalDataRequest{
-			ReqId:          protofmt.Int32(req.ID()),
-			Contract:       opts.Contract.Proto(nil),
-			EndDateTime:    protofmt.String(opts.EndDate.Format("20060102-15:04:05")),
-			BarSizeSetting: protofmt.String(opts.BarSize.String()),
-			Duration:       protofmt.String(strconv.Itoa(opts.Duration) + " " + opts.DurationUnit.String()),
-			UseRTH:         protofmt.Bool(opts.OnlyRegularTradingHours),
-			WhatToShow:     protofmt.String(opts.WhatToShow.String()),
-			FormatDate:     protofmt.Int32(2), // Return epoch timestamp
-			KeepUpToDate:   protofmt.Bool(false),
+	if c.isProtoBufAvailable(common.REQ_HEAD_TIMESTAMP) {
+		pb := protobuf.HeadTimestampRequest{
+			ReqId:      protofmt.Int32(req.ID()),
+			Contract:   opts.Contract.Proto(nil),
+			UseRTH:     protofmt.Bool(opts.OnlyRegularTradingHours),
+			WhatToShow: protofmt.String(opts.WhatToShow.String()),
+			FormatDate: protofmt.Int32(2), // Return epoch timestamp
 		}
 		msgEnc = message.NewEncoder().
-			RawUInt32(common.REQ_HISTORICAL_DATA + common.PROTOBUF_MSG_ID).
+			RawUInt32(common.REQ_HEAD_TIMESTAMP + common.PROTOBUF_MSG_ID).
 			Proto(&pb)
 	} else {
-		msgEnc = message.NewEncoder().Reserve(20).
-			RawUInt32(common.REQ_HISTORICAL_DATA).
+		msgEnc = message.NewEncoder().Reserve(18).
+			RawUInt32(common.REQ_HEAD_TIMESTAMP).
 			RequestID(req.ID()).
 			Marshal(opts.Contract, 2).
-			String(opts.EndDate.Format("20060102-15:04:05")).
-			String(opts.BarSize.String()).
-			String(strconv.Itoa(opts.Duration) + " " + opts.DurationUnit.String()).
 			Bool(opts.OnlyRegularTradingHours).
 			String(opts.WhatToShow.String()).
 			Int(2) // Return epoch timestamp
-		if opts.Contract.SecType == models.SecurityTypePair {
-			msgEnc.Int(len(opts.Contract.ComboLegs))
-			for _, comboLeg := range opts.Contract.ComboLegs {
-				msgEnc.Marshal(comboLeg, 1)
-			}
-		}
-		msgEnc.Bool(false). // KeepUpToDate
-					Marshal(&models.TagValueList{}, 1)
 	}
 	if msgEnc.Err() != nil {
 		return nil, msgEnc.Err()
@@ -353,6 +528,10 @@ func (c *Client) RequestHistoricalData(ctx context.Context, opts models.Historic
 	// Wait until the response is fulfilled
 	err = c.waitRequestCompletion(ctx, req)
 	if err != nil {
+		// Let the server know we are no longer interested in the answer
+		if ctx.Err() != nil {
+			c.cancelHeadTimestamp(req)
+		}
 		return nil, err
 	}
 
@@ -360,6 +539,78 @@ func (c *Client) RequestHistoricalData(ctx context.Context, opts models.Historic
 	return resp, nil
 }
 
+// RequestHistogramData retrieves the volume traded at each price of the given contract during the period. The
+// period is a number followed by a unit, i.e.: "3 days" or "1 week".
+func (c *Client) RequestHistogramData(ctx context.Context, contract *models.Contract, useRTH bool, period string) ([]models.HistogramData, error) {
+	// Validate options
+	if contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	if len(period) == 0 {
+		return nil, errors.New("invalid period")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.HistogramDataResponse{
+		Data: make([]models.HistogramData, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_HISTOGRAM_DATA,
+		Response: resp,
+	})
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_HISTOGRAM_DATA) {
+		pb := protobuf.HistogramDataRequest{
+			ReqId:      protofmt.Int32(req.ID()),
+			Contract:   contract.Proto(nil),
+			UseRTH:     protofmt.Bool(useRTH),
+			TimePeriod: protofmt.String(period),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_HISTOGRAM_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(17).
+			RawUInt32(common.REQ_HISTOGRAM_DATA).
+			RequestID(req.ID()).
+			Marshal(contract, 2).
+			Bool(useRTH).
+			String(period)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		// Let the server know we are no longer interested in the answer
+		if ctx.Err() != nil {
+			c.cancelHistogramData(req)
+		}
+		return nil, err
+	}
+
+	// Done
+	return resp.Data, nil
+}
+
 // RequestHistoricalTicks retrieves historical market ticks.
 func (c *Client) RequestHistoricalTicks(ctx context.Context, opts models.HistoricalTicksRequestOptions) (*models.HistoricalTicksResponse, error) {
 	// Validate options
@@ -599,6 +850,8 @@ func (c *Client) RequestMarketDataType(_ context.Context, opts models.MarketData
 	return nil
 }
 
+// RequestTopMarketData subscribes to the top of book market data of a contract. Besides the ticks, the parameters
+// applied by the server are delivered through the RequestParams channel of the returned handle.
 func (c *Client) RequestTopMarketData(_ context.Context, opts models.TopMarketDataRequestOptions) (*models.TopMarketDataResponse, error) {
 	// Validate options
 	if opts.Contract == nil {
@@ -616,7 +869,8 @@ func (c *Client) RequestTopMarketData(_ context.Context, opts models.TopMarketDa
 
 	// Create the new request and response holder
 	resp := &models.TopMarketDataResponse{
-		Channel: make(chan models.TopMarketData, 4),
+		Channel:       make(chan models.TopMarketData, 4),
+		RequestParams: make(chan *models.TopMarketDataRequestParams, 1),
 	}
 	req := c.createRequest(RequestOptions{
 		Type:     RequestTypeRequestWithTickerID,
@@ -624,6 +878,7 @@ func (c *Client) RequestTopMarketData(_ context.Context, opts models.TopMarketDa
 		Response: resp,
 		CompleteCB: func(req *Request, err error) {
 			close(resp.Channel)
+			close(resp.RequestParams)
 		},
 	})
 	resp.Cancel = func() {
@@ -642,6 +897,10 @@ func (c *Client) RequestTopMarketData(_ context.Context, opts models.TopMarketDa
 			_, _ = genericTickSB.WriteRune(',')
 		}
 		_, _ = genericTickSB.WriteString(strconv.Itoa(int(gt)))
+		if gt == models.GenericTickNews && len(opts.NewsProviders) > 0 {
+			_, _ = genericTickSB.WriteRune(':')
+			_, _ = genericTickSB.WriteString(strings.Join(opts.NewsProviders, "+"))
+		}
 	}
 
 	if c.isProtoBufAvailable(common.REQ_MKT_DATA) {
@@ -693,16 +952,20 @@ func (c *Client) RequestTopMarketData(_ context.Context, opts models.TopMarketDa
 	return resp, nil
 }
 
-func (c *Client) RequestMarketDepthData(_ context.Context, opts models.MarketDepthDataRequestOptions) (*models.MarketDepthDataResponse, error) {
+// SubscribeRealTimeBars streams 5 seconds bars of the given contract. Only trades, midpoint, bid and ask bars are
+// supported.
+func (c *Client) SubscribeRealTimeBars(_ context.Context, contract *models.Contract, whatToShow models.WhatToShow, useRTH bool) (*models.RealTimeBarsResponse, error) {
 	// Validate options
-	if opts.Contract == nil {
+	if contract == nil {
 		return nil, errors.New("invalid contract")
 	}
-	if opts.RowsCount < 0 || opts.RowsCount > 1000 {
-		return nil, errors.New("invalid rows count")
-	}
-	if opts.RowsCount == 0 {
-		opts.RowsCount = 20
+	switch whatToShow {
+	case models.WhatToShowTrades:
+	case models.WhatToShowMidPoint:
+	case models.WhatToShowBid:
+	case models.WhatToShowAsk:
+	default:
+		return nil, errors.New("invalid what to show")
 	}
 
 	// Rundown protect
@@ -712,20 +975,19 @@ func (c *Client) RequestMarketDepthData(_ context.Context, opts models.MarketDep
 	defer c.rp.Release()
 
 	// Create the new request and response holder
-	resp := &models.MarketDepthDataResponse{
-		Book:    models.NewMarketDepthBook(opts.RowsCount),
-		Channel: make(chan models.MarketDepthData, 4),
+	resp := &models.RealTimeBarsResponse{
+		Channel: make(chan models.RealTimeBar, 4),
 	}
 	req := c.createRequest(RequestOptions{
-		Type:     RequestTypeRequestWithTickerID,
-		MsgCode:  common.REQ_MKT_DEPTH,
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_REAL_TIME_BARS,
 		Response: resp,
 		CompleteCB: func(req *Request, err error) {
 			close(resp.Channel)
 		},
 	})
 	resp.Cancel = func() {
-		c.cancelMarketDepthData(req, opts.SmartDepth)
+		c.cancelRealTimeBars(req)
 	}
 	resp.Err = func() error {
 		return req.Err()
@@ -733,25 +995,27 @@ func (c *Client) RequestMarketDepthData(_ context.Context, opts models.MarketDep
 
 	// Build the message to send
 	var msgEnc *message.Encoder
-	if c.isProtoBufAvailable(common.REQ_MKT_DEPTH) {
-		pb := protobuf.MarketDepthRequest{
-			ReqId:        protofmt.Int32(req.ID()),
-			Contract:     opts.Contract.Proto(nil),
-			NumRows:      protofmt.Int32(int32(opts.RowsCount)),
-			IsSmartDepth: protofmt.Bool(opts.SmartDepth),
+	if c.isProtoBufAvailable(common.REQ_REAL_TIME_BARS) {
+		pb := protobuf.RealTimeBarsRequest{
+			ReqId:      protofmt.Int32(req.ID()),
+			Contract:   contract.Proto(nil),
+			BarSize:    protofmt.Int32(int32(models.RealTimeBarSize / time.Second)),
+			WhatToShow: protofmt.String(whatToShow.String()),
+			UseRTH:     protofmt.Bool(useRTH),
 		}
 		msgEnc = message.NewEncoder().
-			RawUInt32(common.REQ_MKT_DEPTH + common.PROTOBUF_MSG_ID).
+			RawUInt32(common.REQ_REAL_TIME_BARS + common.PROTOBUF_MSG_ID).
 			Proto(&pb)
 	} else {
-		const VERSION = 5
-		msgEnc = message.NewEncoder().Reserve(17).
-			RawUInt32(common.REQ_MKT_DEPTH).
+		const VERSION = 3
+		msgEnc = message.NewEncoder().Reserve(20).
+			RawUInt32(common.REQ_REAL_TIME_BARS).
 			Int(VERSION).
 			RequestID(req.ID()).
-			Marshal(opts.Contract, 1).
-			Int(opts.RowsCount).
-			Bool(opts.SmartDepth).
+			Marshal(contract, 1).
+			Int(int(models.RealTimeBarSize/time.Second)).
+			String(whatToShow.String()).
+			Bool(useRTH).
 			Marshal(&models.TagValueList{}, 1)
 	}
 	if msgEnc.Err() != nil {
@@ -768,26 +1032,2417 @@ func (c *Client) RequestMarketDepthData(_ context.Context, opts models.MarketDep
 	return resp, nil
 }
 
-func (c *Client) cancelTopMarketData(req *Request) {
+// SubscribeTickByTick streams the tick-by-tick data of the given contract. If numberOfTicks is greater than zero, the
+// server first sends up to that amount of historical ticks. The number of simultaneous subscriptions is limited by
+// Options.MaxTickByTickSubscriptions.
+func (c *Client) SubscribeTickByTick(_ context.Context, contract *models.Contract, tickType models.TickByTickType, numberOfTicks int, ignoreSize bool) (*models.TickByTickResponse, error) {
+	// Validate options
+	if contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	if len(tickType.String()) == 0 {
+		return nil, errors.New("invalid tick type")
+	}
+	if numberOfTicks < 0 || numberOfTicks > 1000 {
+		return nil, errors.New("invalid number of ticks")
+	}
+
 	// Rundown protect
 	if !c.rp.Acquire() {
-		return
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Reserve a subscription slot
+	for {
+		count := atomic.LoadInt32(&c.tickByTickCount)
+		if count >= c.tickByTickLimit {
+			return nil, fmt.Errorf("too many tick-by-tick subscriptions (limit is %d)", c.tickByTickLimit)
+		}
+		if atomic.CompareAndSwapInt32(&c.tickByTickCount, count, count+1) {
+			break
+		}
+	}
+	released := int32(0)
+	releaseSlot := func() {
+		if atomic.CompareAndSwapInt32(&released, 0, 1) {
+			atomic.AddInt32(&c.tickByTickCount, -1)
+		}
+	}
+
+	// Create the new request and response holder
+	resp := &models.TickByTickResponse{
+		Channel: make(chan models.TickByTick, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_TICK_BY_TICK_DATA,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+			releaseSlot()
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelTickByTick(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_TICK_BY_TICK_DATA) {
+		pb := protobuf.TickByTickRequest{
+			ReqId:         protofmt.Int32(req.ID()),
+			Contract:      contract.Proto(nil),
+			TickType:      protofmt.String(tickType.String()),
+			NumberOfTicks: protofmt.Int32(int32(numberOfTicks)),
+			IgnoreSize:    protofmt.Bool(ignoreSize),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_TICK_BY_TICK_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(18).
+			RawUInt32(common.REQ_TICK_BY_TICK_DATA).
+			RequestID(req.ID()).
+			Marshal(contract, 1).
+			String(tickType.String()).
+			Int(numberOfTicks).
+			Bool(ignoreSize)
+	}
+	if msgEnc.Err() != nil {
+		releaseSlot()
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		releaseSlot()
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+func (c *Client) RequestMarketDepthData(_ context.Context, opts models.MarketDepthDataRequestOptions) (*models.MarketDepthDataResponse, error) {
+	// Validate options
+	if opts.Contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	if opts.RowsCount < 0 || opts.RowsCount > 1000 {
+		return nil, errors.New("invalid rows count")
+	}
+	if opts.RowsCount == 0 {
+		opts.RowsCount = 20
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.MarketDepthDataResponse{
+		Book:    models.NewMarketDepthBook(opts.RowsCount),
+		Channel: make(chan models.MarketDepthData, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithTickerID,
+		MsgCode:  common.REQ_MKT_DEPTH,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelMarketDepthData(req, opts.SmartDepth)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_MKT_DEPTH) {
+		pb := protobuf.MarketDepthRequest{
+			ReqId:        protofmt.Int32(req.ID()),
+			Contract:     opts.Contract.Proto(nil),
+			NumRows:      protofmt.Int32(int32(opts.RowsCount)),
+			IsSmartDepth: protofmt.Bool(opts.SmartDepth),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_MKT_DEPTH + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 5
+		msgEnc = message.NewEncoder().Reserve(17).
+			RawUInt32(common.REQ_MKT_DEPTH).
+			Int(VERSION).
+			RequestID(req.ID()).
+			Marshal(opts.Contract, 1).
+			Int(opts.RowsCount).
+			Bool(opts.SmartDepth).
+			Marshal(&models.TagValueList{}, 1)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// PlaceOrder submits a new order. Errors, open order and status updates are delivered through the returned handle
+// until the order reaches a final state or it is rejected.
+func (c *Client) PlaceOrder(_ context.Context, opts models.PlaceOrderRequestOptions) (*models.PlaceOrderResponse, error) {
+	// Validate options
+	if opts.Contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	if opts.Order == nil {
+		return nil, errors.New("invalid order")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Allocate a new order ID
+	orderID, err := c.orderIDs.next()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the new request and response holder
+	resp := &models.PlaceOrderResponse{
+		OrderID: orderID,
+		Channel: make(chan models.OrderEvent, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeOrder,
+		MsgCode:  common.PLACE_ORDER,
+		OrderID:  orderID,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Modify = func(order *models.Order) error {
+		if req.IsCompleted() {
+			return errors.New("order is no longer active")
+		}
+		return c.ModifyOrder(context.Background(), models.ModifyOrderRequestOptions{
+			OrderID:  resp.OrderID,
+			Contract: opts.Contract,
+			Order:    order,
+		})
+	}
+	resp.Cancel = func(orderCancel models.OrderCancel) error {
+		if req.IsCompleted() {
+			return errors.New("order is no longer active")
+		}
+		return c.CancelOrder(context.Background(), models.CancelOrderRequestOptions{
+			OrderID:     resp.OrderID,
+			OrderCancel: orderCancel,
+		})
+	}
+	resp.Detach = func() {
+		c.reqMgr.removeRequest(req, nil)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildPlaceOrderMessage(resp.OrderID, opts.Contract, opts.Order)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err = c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// ExerciseOptions sends an instruction to exercise or lapse the given option contract. TWS handles the instruction
+// as an order, so the resulting errors, open order and status updates are delivered through the returned handle. Set
+// override to ignore the exercise settings of the account, i.e.: to exercise an out-of-the-money option.
+func (c *Client) ExerciseOptions(_ context.Context, contract *models.Contract, action models.OptionExercise, quantity int32, account string, override bool, manualOrderTime string) (*models.ExerciseOptionsResponse, error) {
+	// Validate options
+	if contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	if action != models.OptionExerciseExercise && action != models.OptionExerciseLapse {
+		return nil, errors.New("invalid exercise action")
+	}
+	if quantity <= 0 {
+		return nil, errors.New("invalid quantity")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Allocate a new order ID
+	orderID, err := c.orderIDs.next()
+	if err != nil {
+		return nil, err
+	}
+
+	// Create the new request and response holder
+	resp := &models.ExerciseOptionsResponse{
+		OrderID: orderID,
+		Channel: make(chan models.OrderEvent, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeOrder,
+		MsgCode:  common.EXERCISE_OPTIONS,
+		OrderID:  orderID,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Detach = func() {
+		c.reqMgr.removeRequest(req, nil)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	const VERSION = 2
+	msgEnc := message.NewEncoder().Reserve(21).
+		RawUInt32(common.EXERCISE_OPTIONS).
+		Int(VERSION).
+		RequestID(int32(orderID)).
+		Int32(contract.ConID).
+		String(contract.Symbol).
+		String(string(contract.SecType)).
+		String(contract.LastTradeDateOrContractMonth).
+		FloatMax(contract.Strike).
+		String(contract.Right).
+		FloatMax(contract.Multiplier).
+		String(contract.Exchange).
+		String(contract.Currency).
+		String(contract.LocalSymbol).
+		String(contract.TradingClass).
+		Int32(int32(action)).
+		Int32(quantity).
+		String(account).
+		Bool(override).
+		String(manualOrderTime).
+		String(""). // Customer account
+		Bool(false) // Professional customer
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err = c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// ModifyOrder sends a new version of an already placed order. The updates are delivered through the handle returned
+// by PlaceOrder.
+func (c *Client) ModifyOrder(_ context.Context, opts models.ModifyOrderRequestOptions) error {
+	// Validate options
+	if opts.OrderID <= 0 {
+		return errors.New("invalid order id")
+	}
+	if opts.Contract == nil {
+		return errors.New("invalid contract")
+	}
+	if opts.Order == nil {
+		return errors.New("invalid order")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	msgEnc := c.buildPlaceOrderMessage(opts.OrderID, opts.Contract, opts.Order)
+	if msgEnc.Err() != nil {
+		return msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendMessage(msgEnc.Bytes())
+	if err != nil {
+		return err
+	}
+
+	// Done
+	return nil
+}
+
+// CancelOrder requests the cancellation of an already placed order. The resulting status update is delivered through
+// the handle returned by PlaceOrder.
+func (c *Client) CancelOrder(_ context.Context, opts models.CancelOrderRequestOptions) error {
+	// Validate options
+	if opts.OrderID <= 0 {
+		return errors.New("invalid order id")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_ORDER) {
+		pb := protobuf.CancelOrderRequest{
+			OrderId:     protofmt.Int32(int32(opts.OrderID)),
+			OrderCancel: opts.OrderCancel.Proto(),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_ORDER + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(5).
+			RawUInt32(common.CANCEL_ORDER).
+			Int32(int32(opts.OrderID)).
+			String(opts.OrderCancel.ManualOrderCancelTime).
+			String(opts.OrderCancel.ExtOperator).
+			Int32Max(opts.OrderCancel.ManualOrderIndicator)
+	}
+	if msgEnc.Err() != nil {
+		return msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendMessage(msgEnc.Bytes())
+	if err != nil {
+		return err
+	}
+
+	// Done
+	return nil
+}
+
+// RequestOpenOrders retrieves the open orders placed by this client.
+func (c *Client) RequestOpenOrders(ctx context.Context) ([]*models.OpenOrder, error) {
+	return c.requestOpenOrders(ctx, common.REQ_OPEN_ORDERS)
+}
+
+// RequestAllOpenOrders retrieves the open orders placed by all clients and the ones entered in TWS.
+func (c *Client) RequestAllOpenOrders(ctx context.Context) ([]*models.OpenOrder, error) {
+	return c.requestOpenOrders(ctx, common.REQ_ALL_OPEN_ORDERS)
+}
+
+// RequestAutoOpenOrders binds the orders entered in TWS to this client and streams their open order and status
+// updates. Only available when connected with client ID 0. See Options.UseClientIDZero.
+func (c *Client) RequestAutoOpenOrders(_ context.Context) (*models.AutoOpenOrdersResponse, error) {
+	// Validate options
+	if c.clientID != 0 {
+		return nil, errors.New("auto open orders binding requires client id 0")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one binding can be active at a time
+	if !atomic.CompareAndSwapInt32(&c.autoOpenOrdersActive, 0, 1) {
+		return nil, errors.New("auto open orders binding already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.AutoOpenOrdersResponse{
+		Channel: make(chan models.OrderEvent, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_AUTO_OPEN_ORDERS,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+			atomic.StoreInt32(&c.autoOpenOrdersActive, 0)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelAutoOpenOrders(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildAutoOpenOrdersMessage(true)
+	if msgEnc.Err() != nil {
+		atomic.StoreInt32(&c.autoOpenOrdersActive, 0)
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		atomic.StoreInt32(&c.autoOpenOrdersActive, 0)
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestCompletedOrders retrieves the orders completed during the current day. If apiOnly is true, only the orders
+// placed through the API are returned.
+func (c *Client) RequestCompletedOrders(ctx context.Context, apiOnly bool) ([]*models.CompletedOrder, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.CompletedOrdersResponse{
+		CompletedOrders: make([]*models.CompletedOrder, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_COMPLETED_ORDERS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_COMPLETED_ORDERS) {
+		pb := protobuf.CompletedOrdersRequest{
+			ApiOnly: protofmt.Bool(apiOnly),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_COMPLETED_ORDERS + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_COMPLETED_ORDERS).
+			Bool(apiOnly)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.CompletedOrders, nil
+}
+
+// RequestExecutions retrieves the executions matching the given filter, paired with their commission and fees
+// reports. A nil filter returns all the executions of the current day. Once the server signals the end of the list,
+// the missing reports are awaited for up to 5 seconds and left empty if they do not arrive. The context deadline must
+// allow for that wait.
+func (c *Client) RequestExecutions(ctx context.Context, filter *models.ExecutionFilter) ([]*models.ExecutionDetails, error) {
+	// Validate options
+	if filter == nil {
+		filter = models.NewExecutionFilter()
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.ExecutionsResponse{
+		Executions: make([]*models.ExecutionDetails, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_EXECUTIONS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_EXECUTIONS) {
+		pb := protobuf.ExecutionRequest{
+			ReqId:           protofmt.Int32(req.ID()),
+			ExecutionFilter: filter.Proto(),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_EXECUTIONS + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 3
+		msgEnc = message.NewEncoder().Reserve(13).
+			RawUInt32(common.REQ_EXECUTIONS).
+			Int(VERSION).
+			RequestID(req.ID()).
+			Marshal(filter, 1)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.execTracker.untrackRequest(req.ID())
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Executions, nil
+}
+
+// SubscribeFills streams the live executions of this client. Each fill is emitted once its commission and fees
+// report arrives or, if it does not, after the configured timeout.
+func (c *Client) SubscribeFills(_ context.Context, opts models.FillsRequestOptions) (*models.FillsResponse, error) {
+	// Validate options
+	if opts.CommissionReportTimeout < 0 {
+		return nil, errors.New("invalid commission report timeout")
+	}
+	if opts.CommissionReportTimeout == 0 {
+		opts.CommissionReportTimeout = defaultCommissionReportTimeout
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one subscription can be active at a time
+	if !c.execTracker.startFills(opts.CommissionReportTimeout) {
+		return nil, errors.New("fills subscription already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.FillsResponse{
+		Channel: make(chan *models.ExecutionDetails, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_EXECUTIONS,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			c.execTracker.stopFills()
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.reqMgr.removeRequest(req, nil)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Register it. Live executions are sent by the server without being requested.
+	err := c.registerRequest(req)
+	if err != nil {
+		c.execTracker.stopFills()
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// SubscribeAccountUpdates streams the account values and portfolio of the given account. The server only supports
+// one account updates subscription at a time.
+func (c *Client) SubscribeAccountUpdates(_ context.Context, account string) (*models.AccountUpdatesResponse, error) {
+	// Validate options
+	if len(account) == 0 {
+		return nil, errors.New("invalid account")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one subscription can be active at a time
+	if !atomic.CompareAndSwapInt32(&c.accountUpdatesActive, 0, 1) {
+		return nil, errors.New("account updates subscription already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.AccountUpdatesResponse{
+		Channel:  make(chan models.AccountUpdate, 4),
+		Snapshot: models.NewAccountSnapshot(),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_ACCT_DATA,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+			atomic.StoreInt32(&c.accountUpdatesActive, 0)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelAccountUpdates(req, account)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildAccountUpdatesMessage(true, account)
+	if msgEnc.Err() != nil {
+		atomic.StoreInt32(&c.accountUpdatesActive, 0)
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		atomic.StoreInt32(&c.accountUpdatesActive, 0)
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestAccountSummary retrieves the given summary tags of the accounts in the group. Use
+// models.AccountSummaryGroupAll to get all the accounts.
+func (c *Client) RequestAccountSummary(ctx context.Context, group string, tags []models.AccountSummaryTag) (models.AccountSummary, error) {
+	// Validate options
+	if len(group) == 0 {
+		return nil, errors.New("invalid group")
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("invalid tags")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.AccountSummarySnapshotResponse{
+		Summary: models.NewAccountSummary(),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_ACCOUNT_SUMMARY,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := c.buildAccountSummaryMessage(req.ID(), group, tags)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	// The subscription remains active on the server after the end, so always cancel it
+	defer c.cancelAccountSummary(req)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Summary, nil
+}
+
+// SubscribeAccountSummary streams the given summary tags of the accounts in the group. After the initial values, the
+// server sends the ones that changed every three minutes.
+func (c *Client) SubscribeAccountSummary(_ context.Context, group string, tags []models.AccountSummaryTag) (*models.AccountSummaryResponse, error) {
+	// Validate options
+	if len(group) == 0 {
+		return nil, errors.New("invalid group")
+	}
+	if len(tags) == 0 {
+		return nil, errors.New("invalid tags")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.AccountSummaryResponse{
+		Channel: make(chan models.AccountSummaryUpdate, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_ACCOUNT_SUMMARY,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelAccountSummary(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildAccountSummaryMessage(req.ID(), group, tags)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// SubscribeAccountUpdatesMulti streams the account values of the given account and model code. A
+// *models.AccountMultiEnd is sent after the initial values. If ledgerAndNLV is true, only the ledger and net
+// liquidation values are sent. Unlike SubscribeAccountUpdates, several subscriptions can be active at once.
+func (c *Client) SubscribeAccountUpdatesMulti(_ context.Context, account string, modelCode string, ledgerAndNLV bool) (*models.AccountUpdatesMultiResponse, error) {
+	// Validate options
+	if len(account) == 0 && len(modelCode) == 0 {
+		return nil, errors.New("invalid account")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.AccountUpdatesMultiResponse{
+		Channel: make(chan models.AccountUpdateMulti, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_ACCOUNT_UPDATES_MULTI,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelAccountUpdatesMulti(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.REQ_ACCOUNT_UPDATES_MULTI) {
+		pb := protobuf.AccountUpdatesMultiRequest{
+			ReqId:        protofmt.Int32(req.ID()),
+			Account:      protofmt.String(account),
+			ModelCode:    protofmt.String(modelCode),
+			LedgerAndNLV: protofmt.Bool(ledgerAndNLV),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.REQ_ACCOUNT_UPDATES_MULTI + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(6).
+			RawUInt32(common.REQ_ACCOUNT_UPDATES_MULTI).
+			Int(VERSION).
+			RequestID(req.ID()).
+			String(account).
+			String(modelCode).
+			Bool(ledgerAndNLV)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestPositions retrieves the positions of all the accessible accounts. The server only supports one positions
+// request at a time, so it fails if a positions subscription is active.
+func (c *Client) RequestPositions(ctx context.Context) ([]models.Position, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one request can be active at a time
+	if !atomic.CompareAndSwapInt32(&c.positionsActive, 0, 1) {
+		return nil, errors.New("positions subscription already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.PositionsSnapshotResponse{
+		Positions: make([]models.Position, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_POSITIONS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := c.buildPositionsMessage(true)
+	if msgEnc.Err() != nil {
+		atomic.StoreInt32(&c.positionsActive, 0)
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		atomic.StoreInt32(&c.positionsActive, 0)
+		return nil, err
+	}
+	// The subscription remains active on the server after the end, so always cancel it before allowing a new one
+	defer func() {
+		c.cancelPositions(req)
+		atomic.StoreInt32(&c.positionsActive, 0)
+	}()
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Positions, nil
+}
+
+// SubscribePositions streams the positions of all the accessible accounts. A *models.PositionEnd is sent after the
+// initial positions and, after it, the server sends the positions that changed.
+func (c *Client) SubscribePositions(_ context.Context) (*models.PositionsResponse, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one subscription can be active at a time
+	if !atomic.CompareAndSwapInt32(&c.positionsActive, 0, 1) {
+		return nil, errors.New("positions subscription already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.PositionsResponse{
+		Channel: make(chan models.PositionUpdate, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_POSITIONS,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+			atomic.StoreInt32(&c.positionsActive, 0)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelPositions(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildPositionsMessage(true)
+	if msgEnc.Err() != nil {
+		atomic.StoreInt32(&c.positionsActive, 0)
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		atomic.StoreInt32(&c.positionsActive, 0)
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestPositionsMulti retrieves the positions of the given account and model code. Leave modelCode empty to get
+// the positions not assigned to a model.
+func (c *Client) RequestPositionsMulti(ctx context.Context, account string, modelCode string) ([]models.Position, error) {
+	// Validate options
+	if len(account) == 0 && len(modelCode) == 0 {
+		return nil, errors.New("invalid account")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.PositionsSnapshotResponse{
+		Positions: make([]models.Position, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_POSITIONS_MULTI,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := c.buildPositionsMultiMessage(req.ID(), account, modelCode)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	// The subscription remains active on the server after the end, so always cancel it
+	defer c.cancelPositionsMulti(req)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Positions, nil
+}
+
+// SubscribePositionsMulti streams the positions of the given account and model code. A *models.PositionEnd is sent
+// after the initial positions and, after it, the server sends the positions that changed.
+func (c *Client) SubscribePositionsMulti(_ context.Context, account string, modelCode string) (*models.PositionsResponse, error) {
+	// Validate options
+	if len(account) == 0 && len(modelCode) == 0 {
+		return nil, errors.New("invalid account")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.PositionsResponse{
+		Channel: make(chan models.PositionUpdate, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_POSITIONS_MULTI,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelPositionsMulti(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := c.buildPositionsMultiMessage(req.ID(), account, modelCode)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// SubscribePnL streams the daily profit and loss of the given account and model code.
+func (c *Client) SubscribePnL(_ context.Context, account string, modelCode string) (*models.PnLResponse, error) {
+	// Validate options
+	if len(account) == 0 {
+		return nil, errors.New("invalid account")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.PnLResponse{
+		Channel: make(chan *models.PnL, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_PNL,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelPnL(req, common.CANCEL_PNL)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(4).
+		RawUInt32(common.REQ_PNL).
+		RequestID(req.ID()).
+		String(account).
+		String(modelCode)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// SubscribePnLSingle streams the daily profit and loss of a single position of the given account and model code.
+func (c *Client) SubscribePnLSingle(_ context.Context, account string, modelCode string, conID int32) (*models.PnLResponse, error) {
+	// Validate options
+	if len(account) == 0 {
+		return nil, errors.New("invalid account")
+	}
+	if conID <= 0 {
+		return nil, errors.New("invalid contract ID")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.PnLResponse{
+		Channel: make(chan *models.PnL, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_PNL_SINGLE,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelPnL(req, common.CANCEL_PNL_SINGLE)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(5).
+		RawUInt32(common.REQ_PNL_SINGLE).
+		RequestID(req.ID()).
+		String(account).
+		String(modelCode).
+		Int32(conID)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestScannerParameters requests the instruments, locations, scan codes and filters available to the market
+// scanner.
+func (c *Client) RequestScannerParameters(ctx context.Context) (*models.ScannerParameters, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.ScannerParametersResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_SCANNER_PARAMETERS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(common.REQ_SCANNER_PARAMETERS).
+		Int(VERSION)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Parameters, nil
+}
+
+// SubscribeScanner starts a market scanner subscription. Each received result set is a whole ranked snapshot that
+// replaces the previous one. The filter options use the filter codes returned by RequestScannerParameters as tags.
+func (c *Client) SubscribeScanner(_ context.Context, subscription *models.ScannerSubscription, filterOptions []models.TagValue) (*models.ScannerResponse, error) {
+	// Validate options
+	if subscription == nil {
+		return nil, errors.New("invalid scanner subscription")
+	}
+	if len(subscription.Instrument) == 0 || len(subscription.LocationCode) == 0 || len(subscription.ScanCode) == 0 {
+		return nil, errors.New("invalid scanner subscription")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.ScannerResponse{
+		Channel: make(chan []*models.ScannerResult, 1),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_SCANNER_SUBSCRIPTION,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelScanner(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	filterOptionsList := models.TagValueList(filterOptions)
+	msgEnc := message.NewEncoder().Reserve(26).
+		RawUInt32(common.REQ_SCANNER_SUBSCRIPTION).
+		RequestID(req.ID()).
+		Marshal(subscription, 1).
+		Marshal(&filterOptionsList, 1).
+		Marshal(&models.TagValueList{}, 1)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestNewsProviders requests the news providers the user is subscribed to.
+func (c *Client) RequestNewsProviders(ctx context.Context) ([]models.NewsProvider, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.NewsProvidersResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_NEWS_PROVIDERS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().
+		RawUInt32(common.REQ_NEWS_PROVIDERS)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Providers, nil
+}
+
+// RequestHistoricalNews requests up to maxResults headlines of the given contract published by the given providers
+// between start and end. Zero times leave the corresponding end of the range open. HasMore is set if more headlines
+// are available.
+func (c *Client) RequestHistoricalNews(ctx context.Context, conID int32, providerCodes []string, start time.Time, end time.Time, maxResults int) (*models.HistoricalNewsResponse, error) {
+	// Validate options
+	if conID <= 0 {
+		return nil, errors.New("invalid contract id")
+	}
+	if len(providerCodes) == 0 {
+		return nil, errors.New("invalid provider codes")
+	}
+	if maxResults < 1 || maxResults > 300 {
+		return nil, errors.New("invalid max results")
+	}
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, errors.New("invalid date range")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.HistoricalNewsResponse{
+		News: make([]*models.HistoricalNews, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_HISTORICAL_NEWS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	startDateTime := ""
+	if !start.IsZero() {
+		startDateTime = start.UTC().Format(models.HistoricalNewsTimeLayout)
+	}
+	endDateTime := ""
+	if !end.IsZero() {
+		endDateTime = end.UTC().Format(models.HistoricalNewsTimeLayout)
+	}
+	msgEnc := message.NewEncoder().Reserve(8).
+		RawUInt32(common.REQ_HISTORICAL_NEWS).
+		RequestID(req.ID()).
+		Int32(conID).
+		String(strings.Join(providerCodes, "+")).
+		String(startDateTime).
+		String(endDateTime).
+		Int(maxResults).
+		Marshal(&models.TagValueList{}, 1)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestNewsArticle requests the body of a news article. Binary articles are base64 encoded PDFs, use
+// NewsArticle.PDF to decode them.
+func (c *Client) RequestNewsArticle(ctx context.Context, providerCode string, articleID string) (*models.NewsArticle, error) {
+	// Validate options
+	if len(providerCode) == 0 {
+		return nil, errors.New("invalid provider code")
+	}
+	if len(articleID) == 0 {
+		return nil, errors.New("invalid article id")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.NewsArticleResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_NEWS_ARTICLE,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(5).
+		RawUInt32(common.REQ_NEWS_ARTICLE).
+		RequestID(req.ID()).
+		String(providerCode).
+		String(articleID).
+		Marshal(&models.TagValueList{}, 1)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Article, nil
+}
+
+// SubscribeNewsBulletins streams the IB news bulletins. If allMessages is true, the bulletins of the current day are
+// sent first. Only one subscription can be active at a time.
+func (c *Client) SubscribeNewsBulletins(_ context.Context, allMessages bool) (*models.NewsBulletinsResponse, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Only one subscription can be active at a time
+	if !atomic.CompareAndSwapInt32(&c.newsBulletinsActive, 0, 1) {
+		return nil, errors.New("news bulletins subscription already active")
+	}
+
+	// Create the new request and response holder
+	resp := &models.NewsBulletinsResponse{
+		Channel: make(chan *models.NewsBulletin, 4),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_NEWS_BULLETINS,
+		Response: resp,
+		CompleteCB: func(req *Request, err error) {
+			close(resp.Channel)
+			atomic.StoreInt32(&c.newsBulletinsActive, 0)
+		},
+	})
+	resp.Cancel = func() {
+		c.cancelNewsBulletins(req)
+	}
+	resp.Err = func() error {
+		return req.Err()
+	}
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(common.REQ_NEWS_BULLETINS).
+		Int(VERSION).
+		Bool(allMessages)
+	if msgEnc.Err() != nil {
+		atomic.StoreInt32(&c.newsBulletinsActive, 0)
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		atomic.StoreInt32(&c.newsBulletinsActive, 0)
+		return nil, err
+	}
+
+	// Done
+	return resp, nil
+}
+
+// RequestFundamentalData retrieves a fundamental data report of the given contract. The raw XML is always returned.
+// Snapshot and financial summary reports are also parsed. If parsing fails, the report is returned along with the
+// error.
+func (c *Client) RequestFundamentalData(ctx context.Context, contract *models.Contract, reportType models.FundamentalReportType) (*models.FundamentalData, error) {
+	// Validate options
+	if contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+	switch reportType {
+	case models.FundamentalReportTypeSnapshot:
+	case models.FundamentalReportTypeFinSummary:
+	case models.FundamentalReportTypeRatios:
+	case models.FundamentalReportTypeFinStatements:
+	case models.FundamentalReportTypeEstimates:
+	default:
+		return nil, errors.New("invalid report type")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.FundamentalDataResponse{
+		ReportType: reportType,
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_FUNDAMENTAL_DATA,
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 2
+	msgEnc := message.NewEncoder().Reserve(12).
+		RawUInt32(common.REQ_FUNDAMENTAL_DATA).
+		Int(VERSION).
+		RequestID(req.ID()).
+		Int32(contract.ConID).
+		String(contract.Symbol).
+		String(string(contract.SecType)).
+		String(contract.Exchange).
+		String(contract.PrimaryExchange).
+		String(contract.Currency).
+		String(contract.LocalSymbol).
+		String(string(reportType)).
+		Marshal(&models.TagValueList{}, 1)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		// Let the server know we are no longer interested in the answer
+		if ctx.Err() != nil {
+			c.cancelFundamentalData(req)
+		}
+		return nil, err
+	}
+
+	// Done
+	return resp.Data, resp.ParseErr
+}
+
+// RequestOptionChainParams retrieves the option chains of the given underlying, one per exchange. Leave
+// futFopExchange empty for non-futures underlyings. Use OptionChainParams.Contracts to expand a chain into contracts.
+func (c *Client) RequestOptionChainParams(ctx context.Context, underlyingSymbol string, futFopExchange string, underlyingSecType models.SecurityType, underlyingConID int32) ([]*models.OptionChainParams, error) {
+	// Validate options
+	if len(underlyingSymbol) == 0 {
+		return nil, errors.New("invalid underlying symbol")
+	}
+	if len(underlyingSecType) == 0 {
+		return nil, errors.New("invalid underlying security type")
+	}
+	if underlyingConID <= 0 {
+		return nil, errors.New("invalid underlying contract id")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.OptionChainParamsResponse{
+		Params: make([]*models.OptionChainParams, 0),
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_SEC_DEF_OPT_PARAMS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(6).
+		RawUInt32(common.REQ_SEC_DEF_OPT_PARAMS).
+		RequestID(req.ID()).
+		String(underlyingSymbol).
+		String(futFopExchange).
+		String(string(underlyingSecType)).
+		Int32(underlyingConID)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Params, nil
+}
+
+// CalculateImpliedVolatility asks TWS to calculate the implied volatility of the given option contract for the given
+// option and underlying prices.
+func (c *Client) CalculateImpliedVolatility(ctx context.Context, contract *models.Contract, optionPrice float64, underPrice float64) (*models.TopMarketDataOptionComputation, error) {
+	return c.calculateOptionComputation(ctx, common.REQ_CALC_IMPLIED_VOLAT, contract, optionPrice, underPrice)
+}
+
+// CalculateOptionPrice asks TWS to calculate the theoretical price and greeks of the given option contract for the
+// given volatility and underlying price.
+func (c *Client) CalculateOptionPrice(ctx context.Context, contract *models.Contract, volatility float64, underPrice float64) (*models.TopMarketDataOptionComputation, error) {
+	return c.calculateOptionComputation(ctx, common.REQ_CALC_OPTION_PRICE, contract, volatility, underPrice)
+}
+
+// RequestMarketRule retrieves the price increments of the given market rule. Rules are cached so only the first
+// request of each rule reaches the server.
+func (c *Client) RequestMarketRule(ctx context.Context, ruleID int32) (*models.MarketRule, error) {
+	// Validate options
+	if ruleID < 0 {
+		return nil, errors.New("invalid market rule id")
+	}
+
+	// Check the cache first
+	if rule, ok := c.marketRules.get(ruleID); ok {
+		return rule, nil
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.MarketRuleResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_MARKET_RULE,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(common.REQ_MARKET_RULE).
+		Int32(ruleID)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it. Responses carry the rule ID instead of a request ID so the request is queued by rule.
+	c.marketRules.addWaiter(ruleID, req)
+	defer c.marketRules.removeWaiter(ruleID, req)
+	err := c.sendMessage(msgEnc.Bytes())
+	if err != nil {
+		return nil, err
+	}
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Rule, nil
+}
+
+// RoundLimitPrice rounds the price to the nearest valid tick of the contract on the given exchange, using the market
+// rule that applies at that price level. If exchange is empty, the exchange of the contract is used.
+func (c *Client) RoundLimitPrice(ctx context.Context, contractDetails *models.ContractDetails, exchange string, price float64) (float64, error) {
+	// Validate options
+	if contractDetails == nil || contractDetails.Contract == nil {
+		return 0, errors.New("invalid contract details")
+	}
+	if len(exchange) == 0 {
+		exchange = contractDetails.Contract.Exchange
+	}
+	ruleID, ok := contractDetails.MarketRuleID(exchange)
+	if !ok {
+		return 0, errors.New("no market rule for exchange")
+	}
+
+	// Get the rule
+	rule, err := c.RequestMarketRule(ctx, ruleID)
+	if err != nil {
+		return 0, err
+	}
+
+	// Done
+	return rule.RoundPrice(price), nil
+}
+
+// RequestSmartComponents retrieves the exchanges included in the given BBO exchange, along with the letters used to
+// identify them in SMART market depth data. The BBO exchange is reported by the server when market data is requested.
+func (c *Client) RequestSmartComponents(ctx context.Context, bboExchange string) ([]models.SmartComponent, error) {
+	// Validate options
+	if len(bboExchange) == 0 {
+		return nil, errors.New("invalid bbo exchange")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.SmartComponentsResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REQ_SMART_COMPONENTS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(common.REQ_SMART_COMPONENTS).
+		RequestID(req.ID()).
+		String(bboExchange)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Components, nil
+}
+
+// RequestMarketDepthExchanges retrieves the exchanges offering market depth data and the kind of data each one
+// provides.
+func (c *Client) RequestMarketDepthExchanges(ctx context.Context) ([]*models.DepthMktDataDescription, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.MarketDepthExchangesResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_MKT_DEPTH_EXCHANGES,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().
+		RawUInt32(common.REQ_MKT_DEPTH_EXCHANGES)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Exchanges, nil
+}
+
+// RequestFamilyCodes retrieves the family codes of the accounts of the user.
+func (c *Client) RequestFamilyCodes(ctx context.Context) ([]models.FamilyCode, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.FamilyCodesResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_FAMILY_CODES,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().
+		RawUInt32(common.REQ_FAMILY_CODES)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.FamilyCodes, nil
+}
+
+// RequestSoftDollarTiers retrieves the soft dollar tiers that can be set in orders.
+func (c *Client) RequestSoftDollarTiers(ctx context.Context) ([]models.SoftDollarTier, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.SoftDollarTiersResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_SOFT_DOLLAR_TIERS,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(common.REQ_SOFT_DOLLAR_TIERS).
+		RequestID(0) // Responses are matched by message code
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.Tiers, nil
+}
+
+// RequestUserInfo retrieves the white branding ID of the user.
+func (c *Client) RequestUserInfo(ctx context.Context) (string, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return "", net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.UserInfoResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_USER_INFO,
+		Response: resp,
+	})
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(common.REQ_USER_INFO).
+		RequestID(0) // Responses are matched by message code
+	if msgEnc.Err() != nil {
+		return "", msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return "", err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return "", err
+	}
+
+	// Done
+	return resp.WhiteBrandingID, nil
+}
+
+// RequestFA retrieves the Financial Advisor configuration of the given type.
+func (c *Client) RequestFA(ctx context.Context, faData models.FaData) (*models.FaConfig, error) {
+	// Validate options
+	if len(faData.String()) == 0 {
+		return nil, errors.New("invalid fa data type")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.FaResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_FA,
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(common.REQ_FA).
+		Int(VERSION).
+		Int32(int32(faData))
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.FaData != faData {
+		return nil, fmt.Errorf("unexpected fa data type received: %d", resp.FaData)
+	}
+
+	// Done
+	return models.NewFaConfigFromXML(faData, resp.XML)
+}
+
+// ReplaceFA replaces the Financial Advisor configuration of the given type with the provided one and waits until
+// the server acknowledges the change.
+func (c *Client) ReplaceFA(ctx context.Context, faData models.FaData, config *models.FaConfig) error {
+	// Validate options
+	if len(faData.String()) == 0 {
+		return errors.New("invalid fa data type")
+	}
+	if config == nil {
+		return errors.New("invalid fa configuration")
+	}
+	doc, err := config.EncodeXML(faData)
+	if err != nil {
+		return err
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.ReplaceFaResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  common.REPLACE_FA,
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(5).
+		RawUInt32(common.REPLACE_FA).
+		Int(VERSION).
+		Int32(int32(faData)).
+		String(doc).
+		RequestID(req.ID())
+	if msgEnc.Err() != nil {
+		return msgEnc.Err()
+	}
+
+	// Send it
+	err = c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// Done
+	return nil
+}
+
+func (c *Client) cancelHistoricalData(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_HISTORICAL_DATA) {
+		pb := protobuf.CancelHistoricalData{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_HISTORICAL_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(3).
+			RawUInt32(common.CANCEL_HISTORICAL_DATA).
+			Int(VERSION).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelHeadTimestamp(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_HEAD_TIMESTAMP) {
+		pb := protobuf.CancelHeadTimestamp{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_HEAD_TIMESTAMP + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(2).
+			RawUInt32(common.CANCEL_HEAD_TIMESTAMP).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelHistogramData(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_HISTOGRAM_DATA) {
+		pb := protobuf.CancelHistogramData{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_HISTOGRAM_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(2).
+			RawUInt32(common.CANCEL_HISTOGRAM_DATA).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelTopMarketData(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_MKT_DATA) {
+		pb := protobuf.CancelMarketData{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_MKT_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 2
+		msgEnc = message.NewEncoder().Reserve(3).
+			RawUInt32(common.CANCEL_MKT_DATA).
+			Int(VERSION).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelRealTimeBars(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_REAL_TIME_BARS) {
+		pb := protobuf.CancelRealTimeBars{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_REAL_TIME_BARS + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(3).
+			RawUInt32(common.CANCEL_REAL_TIME_BARS).
+			Int(VERSION).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelTickByTick(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_TICK_BY_TICK_DATA) {
+		pb := protobuf.CancelTickByTick{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_TICK_BY_TICK_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		msgEnc = message.NewEncoder().Reserve(2).
+			RawUInt32(common.CANCEL_TICK_BY_TICK_DATA).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelMarketDepthData(req *Request, isSmartDepth bool) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_MKT_DEPTH) {
+		pb := protobuf.CancelMarketDepth{
+			ReqId:        protofmt.Int32(req.ID()),
+			IsSmartDepth: protofmt.Bool(isSmartDepth),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_MKT_DEPTH + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(4).
+			RawUInt32(common.CANCEL_MKT_DEPTH).
+			Int(VERSION).
+			RequestID(req.ID()).
+			Bool(isSmartDepth)
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelAutoOpenOrders(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	msgEnc := c.buildAutoOpenOrdersMessage(false)
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelAccountUpdates(req *Request, account string) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	msgEnc := c.buildAccountUpdatesMessage(false, account)
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelAccountSummary(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_ACCOUNT_SUMMARY) {
+		pb := protobuf.CancelAccountSummary{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_ACCOUNT_SUMMARY + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(3).
+			RawUInt32(common.CANCEL_ACCOUNT_SUMMARY).
+			Int(VERSION).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelAccountUpdatesMulti(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	var msgEnc *message.Encoder
+	if c.isProtoBufAvailable(common.CANCEL_ACCOUNT_UPDATES_MULTI) {
+		pb := protobuf.CancelAccountUpdatesMulti{
+			ReqId: protofmt.Int32(req.ID()),
+		}
+		msgEnc = message.NewEncoder().
+			RawUInt32(common.CANCEL_ACCOUNT_UPDATES_MULTI + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	} else {
+		const VERSION = 1
+		msgEnc = message.NewEncoder().Reserve(3).
+			RawUInt32(common.CANCEL_ACCOUNT_UPDATES_MULTI).
+			Int(VERSION).
+			RequestID(req.ID())
+	}
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelPositions(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	msgEnc := c.buildPositionsMessage(false)
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelPositionsMulti(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
 	}
 	defer c.rp.Release()
 
 	// Build the message to send
 	var msgEnc *message.Encoder
-	if c.isProtoBufAvailable(common.CANCEL_MKT_DATA) {
-		pb := protobuf.CancelMarketData{
+	if c.isProtoBufAvailable(common.CANCEL_POSITIONS_MULTI) {
+		pb := protobuf.CancelPositionsMulti{
 			ReqId: protofmt.Int32(req.ID()),
 		}
 		msgEnc = message.NewEncoder().
-			RawUInt32(common.CANCEL_MKT_DATA + common.PROTOBUF_MSG_ID).
+			RawUInt32(common.CANCEL_POSITIONS_MULTI + common.PROTOBUF_MSG_ID).
 			Proto(&pb)
 	} else {
-		const VERSION = 2
+		const VERSION = 1
 		msgEnc = message.NewEncoder().Reserve(3).
-			RawUInt32(common.CANCEL_MKT_DATA).
+			RawUInt32(common.CANCEL_POSITIONS_MULTI).
 			Int(VERSION).
 			RequestID(req.ID())
 	}
@@ -799,40 +3454,438 @@ func (c *Client) cancelTopMarketData(req *Request) {
 	c.reqMgr.removeRequest(req, nil)
 }
 
-func (c *Client) cancelMarketDepthData(req *Request, isSmartDepth bool) {
+func (c *Client) cancelPnL(req *Request, msgCode uint32) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(msgCode).
+		RequestID(req.ID())
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelScanner(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(common.CANCEL_SCANNER_SUBSCRIPTION).
+		Int(VERSION).
+		RequestID(req.ID())
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelNewsBulletins(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(2).
+		RawUInt32(common.CANCEL_NEWS_BULLETINS).
+		Int(VERSION)
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelFundamentalData(req *Request) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return
+	}
+	defer c.rp.Release()
+
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(common.CANCEL_FUNDAMENTAL_DATA).
+		Int(VERSION).
+		RequestID(req.ID())
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) cancelOptionComputation(req *Request, msgCode uint32) {
 	// Rundown protect
 	if !c.rp.Acquire() {
 		return
 	}
 	defer c.rp.Release()
 
+	// Build the message to send
+	const VERSION = 1
+	msgEnc := message.NewEncoder().Reserve(3).
+		RawUInt32(msgCode).
+		Int(VERSION).
+		RequestID(req.ID())
+
+	// Send it
+	_ = c.sendMessage(msgEnc.Bytes())
+
+	// Remove the request from the manager
+	c.reqMgr.removeRequest(req, nil)
+}
+
+func (c *Client) requestOpenOrders(ctx context.Context, msgCode uint32) ([]*models.OpenOrder, error) {
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder. Both kinds of snapshots end with OPEN_ORDER_END, so they share the
+	// same queue.
+	resp := &models.OpenOrdersResponse{
+		OpenOrders: make([]*models.OpenOrder, 0),
+		AllClients: msgCode == common.REQ_ALL_OPEN_ORDERS,
+	}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithoutID,
+		MsgCode:  common.REQ_OPEN_ORDERS,
+		Response: resp,
+	})
+
 	// Build the message to send
 	var msgEnc *message.Encoder
-	if c.isProtoBufAvailable(common.CANCEL_MKT_DEPTH) {
-		pb := protobuf.CancelMarketDepth{
-			ReqId:        protofmt.Int32(req.ID()),
-			IsSmartDepth: protofmt.Bool(isSmartDepth),
-		}
+	if c.isProtoBufAvailable(msgCode) {
 		msgEnc = message.NewEncoder().
-			RawUInt32(common.CANCEL_MKT_DEPTH + common.PROTOBUF_MSG_ID).
-			Proto(&pb)
+			RawUInt32(msgCode + common.PROTOBUF_MSG_ID)
+		if msgCode == common.REQ_ALL_OPEN_ORDERS {
+			msgEnc.Proto(&protobuf.AllOpenOrdersRequest{})
+		} else {
+			msgEnc.Proto(&protobuf.OpenOrdersRequest{})
+		}
 	} else {
 		const VERSION = 1
-		msgEnc = message.NewEncoder().Reserve(4).
-			RawUInt32(common.CANCEL_MKT_DEPTH).
-			Int(VERSION).
-			RequestID(req.ID()).
-			Bool(isSmartDepth)
+		msgEnc = message.NewEncoder().
+			RawUInt32(msgCode).
+			Int(VERSION)
+	}
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
 	}
 
 	// Send it
-	_ = c.sendMessage(msgEnc.Bytes())
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
 
-	// Remove the request from the manager
-	c.reqMgr.removeRequest(req, nil)
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Done
+	return resp.OpenOrders, nil
+}
+
+func (c *Client) calculateOptionComputation(ctx context.Context, msgCode uint32, contract *models.Contract, value float64, underPrice float64) (*models.TopMarketDataOptionComputation, error) {
+	// Validate options
+	if contract == nil {
+		return nil, errors.New("invalid contract")
+	}
+
+	// Rundown protect
+	if !c.rp.Acquire() {
+		return nil, net.ErrClosed
+	}
+	defer c.rp.Release()
+
+	// Create the new request and response holder
+	resp := &models.OptionComputationResponse{}
+	req := c.createRequest(RequestOptions{
+		Type:     RequestTypeRequestWithID,
+		MsgCode:  int(msgCode),
+		Response: resp,
+	})
+
+	// Build the message to send
+	const VERSION = 3
+	msgEnc := message.NewEncoder().Reserve(19).
+		RawUInt32(msgCode).
+		Int(VERSION).
+		RequestID(req.ID()).
+		Marshal(contract, 1).
+		Float(value).
+		Float(underPrice).
+		Int(0). // Options count
+		Marshal(&models.TagValueList{}, 1)
+	if msgEnc.Err() != nil {
+		return nil, msgEnc.Err()
+	}
+
+	// Send it
+	err := c.sendRequest(msgEnc.Bytes(), req)
+	if err != nil {
+		return nil, err
+	}
+	defer c.reqMgr.removeRequest(req, context.Canceled)
+
+	// Wait until the response is fulfilled
+	err = c.waitRequestCompletion(ctx, req)
+	if err != nil {
+		// Let the server know we are no longer interested in the answer
+		if ctx.Err() != nil {
+			if msgCode == common.REQ_CALC_IMPLIED_VOLAT {
+				c.cancelOptionComputation(req, common.CANCEL_CALC_IMPLIED_VOLAT)
+			} else {
+				c.cancelOptionComputation(req, common.CANCEL_CALC_OPTION_PRICE)
+			}
+		}
+		return nil, err
+	}
+
+	// Done
+	return resp.Computation, nil
+}
+
+func (c *Client) buildAutoOpenOrdersMessage(autoBind bool) *message.Encoder {
+	if c.isProtoBufAvailable(common.REQ_AUTO_OPEN_ORDERS) {
+		pb := protobuf.AutoOpenOrdersRequest{
+			AutoBind: protofmt.Bool(autoBind),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.REQ_AUTO_OPEN_ORDERS + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+
+	const VERSION = 1
+	return message.NewEncoder().
+		RawUInt32(common.REQ_AUTO_OPEN_ORDERS).
+		Int(VERSION).
+		Bool(autoBind)
+}
+
+func (c *Client) buildAccountUpdatesMessage(subscribe bool, account string) *message.Encoder {
+	if c.isProtoBufAvailable(common.REQ_ACCT_DATA) {
+		pb := protobuf.AccountDataRequest{
+			Subscribe: protofmt.Bool(subscribe),
+			AcctCode:  protofmt.String(account),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.REQ_ACCT_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+
+	const VERSION = 2
+	return message.NewEncoder().
+		RawUInt32(common.REQ_ACCT_DATA).
+		Int(VERSION).
+		Bool(subscribe).
+		String(account)
+}
+
+func (c *Client) buildAccountSummaryMessage(reqID int32, group string, tags []models.AccountSummaryTag) *message.Encoder {
+	tagsSB := strings.Builder{}
+	for idx, tag := range tags {
+		if idx > 0 {
+			_, _ = tagsSB.WriteRune(',')
+		}
+		_, _ = tagsSB.WriteString(string(tag))
+	}
+
+	if c.isProtoBufAvailable(common.REQ_ACCOUNT_SUMMARY) {
+		pb := protobuf.AccountSummaryRequest{
+			ReqId: protofmt.Int32(reqID),
+			Group: protofmt.String(group),
+			Tags:  protofmt.String(tagsSB.String()),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.REQ_ACCOUNT_SUMMARY + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+
+	const VERSION = 1
+	return message.NewEncoder().Reserve(5).
+		RawUInt32(common.REQ_ACCOUNT_SUMMARY).
+		Int(VERSION).
+		RequestID(reqID).
+		String(group).
+		String(tagsSB.String())
+}
+
+func (c *Client) buildPositionsMessage(subscribe bool) *message.Encoder {
+	if subscribe {
+		if c.isProtoBufAvailable(common.REQ_POSITIONS) {
+			return message.NewEncoder().
+				RawUInt32(common.REQ_POSITIONS + common.PROTOBUF_MSG_ID).
+				Proto(&protobuf.PositionsRequest{})
+		}
+
+		const VERSION = 1
+		return message.NewEncoder().
+			RawUInt32(common.REQ_POSITIONS).
+			Int(VERSION)
+	}
+
+	if c.isProtoBufAvailable(common.CANCEL_POSITIONS) {
+		return message.NewEncoder().
+			RawUInt32(common.CANCEL_POSITIONS + common.PROTOBUF_MSG_ID).
+			Proto(&protobuf.CancelPositions{})
+	}
+
+	const VERSION = 1
+	return message.NewEncoder().
+		RawUInt32(common.CANCEL_POSITIONS).
+		Int(VERSION)
+}
+
+func (c *Client) buildPositionsMultiMessage(reqID int32, account string, modelCode string) *message.Encoder {
+	if c.isProtoBufAvailable(common.REQ_POSITIONS_MULTI) {
+		pb := protobuf.PositionsMultiRequest{
+			ReqId:     protofmt.Int32(reqID),
+			Account:   protofmt.String(account),
+			ModelCode: protofmt.String(modelCode),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.REQ_POSITIONS_MULTI + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+
+	const VERSION = 1
+	return message.NewEncoder().Reserve(5).
+		RawUInt32(common.REQ_POSITIONS_MULTI).
+		Int(VERSION).
+		RequestID(reqID).
+		String(account).
+		String(modelCode)
+}
+
+func (c *Client) buildHistoricalDataMessage(reqID int32, opts models.HistoricalDataRequestOptions, keepUpToDate bool) *message.Encoder {
+	// The end date must be empty when keeping the data up to date
+	endDate := ""
+	if !keepUpToDate {
+		endDate = opts.EndDate.Format("20060102-15:04:05")
+	}
+
+	if c.isProtoBufAvailable(common.REQ_HISTORICAL_DATA) {
+		pb := protobuf.HistoricalDataRequest{
+			ReqId:          protofmt.Int32(reqID),
+			Contract:       opts.Contract.Proto(nil),
+			EndDateTime:    protofmt.String(endDate),
+			BarSizeSetting: protofmt.String(opts.BarSize.String()),
+			Duration:       protofmt.String(strconv.Itoa(opts.Duration) + " " + opts.DurationUnit.String()),
+			UseRTH:         protofmt.Bool(opts.OnlyRegularTradingHours),
+			WhatToShow:     protofmt.String(opts.WhatToShow.String()),
+			FormatDate:     protofmt.Int32(2), // Return epoch timestamp
+			KeepUpToDate:   protofmt.Bool(keepUpToDate),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.REQ_HISTORICAL_DATA + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+
+	msgEnc := message.NewEncoder().Reserve(20).
+		RawUInt32(common.REQ_HISTORICAL_DATA).
+		RequestID(reqID).
+		Marshal(opts.Contract, 2).
+		String(endDate).
+		String(opts.BarSize.String()).
+		String(strconv.Itoa(opts.Duration) + " " + opts.DurationUnit.String()).
+		Bool(opts.OnlyRegularTradingHours).
+		String(opts.WhatToShow.String()).
+		Int(2) // Return epoch timestamp
+	if opts.Contract.SecType == models.SecurityTypePair {
+		msgEnc.Int(len(opts.Contract.ComboLegs))
+		for _, comboLeg := range opts.Contract.ComboLegs {
+			msgEnc.Marshal(comboLeg, 1)
+		}
+	}
+	msgEnc.Bool(keepUpToDate).
+		Marshal(&models.TagValueList{}, 1)
+	return msgEnc
+}
+
+func (c *Client) buildPlaceOrderMessage(orderID models.OrderID, contract *models.Contract, order *models.Order) *message.Encoder {
+	if c.isProtoBufAvailable(common.PLACE_ORDER) {
+		pb := protobuf.PlaceOrderRequest{
+			OrderId:  protofmt.Int32(int32(orderID)),
+			Contract: contract.Proto(order),
+			Order:    order.Proto(),
+		}
+		return message.NewEncoder().
+			RawUInt32(common.PLACE_ORDER + common.PROTOBUF_MSG_ID).
+			Proto(&pb)
+	}
+	return message.NewEncoder().Reserve(180).
+		RawUInt32(common.PLACE_ORDER).
+		Int32(int32(orderID)).
+		Marshal(models.NewOrderEncoder(order, contract), 1)
 }
 
 func (c *Client) isProtoBufAvailable(msgType uint32) bool {
 	minServerVer, ok := common.PROTOBUF_MSG_IDS[msgType]
 	return ok && c.serverVersion >= minServerVer
 }
+
+func validateHistoricalDataRequestOptions(opts models.HistoricalDataRequestOptions, keepUpToDate bool) error {
+	if opts.Contract == nil {
+		return errors.New("invalid contract")
+	}
+	if opts.Duration < 1 {
+		return errors.New("invalid duration")
+	}
+	if len(opts.DurationUnit.String()) == 0 {
+		return errors.New("invalid duration units")
+	}
+	if keepUpToDate {
+		if !opts.EndDate.IsZero() {
+			return errors.New("end date must be empty when keeping data up to date")
+		}
+	} else {
+		if opts.EndDate.IsZero() || opts.EndDate.Before(utils.EpochDate) {
+			return errors.New("invalid end date")
+		}
+	}
+	if len(opts.BarSize.String()) == 0 {
+		return errors.New("invalid bar size")
+	}
+	switch opts.WhatToShow {
+	case models.WhatToShowTrades:
+	case models.WhatToShowMidPoint:
+	case models.WhatToShowBid:
+	case models.WhatToShowAsk:
+	case models.WhatToShowBidAsk:
+	case models.WhatToShowHistoricalVolatility:
+	case models.WhatToShowOptionImpliedVolatility:
+	default:
+		return errors.New("invalid what to show")
+	}
+	if len(opts.WhatToShow.String()) == 0 {
+		return errors.New("invalid what to show")
+	}
+	return nil
+}
@@ -2,6 +2,8 @@ package ibkr_test
 
 import (
 	"context"
+	"os"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
@@ -34,6 +36,14 @@ func TestSuite(t *testing.T) {
 
 		testManagedAccounts(t, client)
 	})
+	t.Run("Next-order-id", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testNextOrderID(t, client)
+	})
 	t.Run("Historical-data", func(t *testing.T) {
 		t.Parallel()
 
@@ -82,6 +92,311 @@ func TestSuite(t *testing.T) {
 
 		testDepthMarketData(t, client)
 	})
+	t.Run("Open-orders", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testOpenOrders(t, client)
+	})
+	t.Run("Completed-orders", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testCompletedOrders(t, client)
+	})
+	t.Run("Executions", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testExecutions(t, client)
+	})
+	t.Run("Account-updates", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testAccountUpdates(t, client)
+	})
+	t.Run("Account-summary", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testAccountSummary(t, client)
+	})
+	t.Run("Account-updates-multi", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testAccountUpdatesMulti(t, client)
+	})
+	t.Run("Positions", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testPositions(t, client)
+	})
+	t.Run("PnL", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testPnL(t, client)
+	})
+	t.Run("Real-time-bars", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testRealTimeBars(t, client)
+	})
+	t.Run("Tick-by-tick", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testTickByTick(t, client)
+	})
+	t.Run("Historical-data-stream", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testHistoricalDataStream(t, client)
+	})
+	t.Run("Head-timestamp", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testHeadTimestamp(t, client)
+	})
+	t.Run("Histogram-data", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testHistogramData(t, client)
+	})
+	t.Run("Scanner", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testScanner(t, client)
+	})
+	t.Run("News", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testNews(t, client)
+	})
+	t.Run("Fundamental-data", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testFundamentalData(t, client)
+	})
+	t.Run("Option-chain", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testOptionChain(t, client)
+	})
+	t.Run("Option-computation", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testOptionComputation(t, client)
+	})
+	t.Run("Market-rule", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testMarketRule(t, client)
+	})
+	t.Run("Market-depth-exchanges", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testMarketDepthExchanges(t, client)
+	})
+	t.Run("Account-metadata", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testAccountMetadata(t, client)
+	})
+	t.Run("Financial-advisor", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testFinancialAdvisor(t, client)
+	})
+	t.Run("Exercise-options", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testExerciseOptions(t, client)
+	})
+	t.Run("What-if-order", func(t *testing.T) {
+		t.Parallel()
+
+		swm := newStopWatchMeasure(t, sw)
+		defer swm.End()
+
+		testWhatIfOrder(t, client)
+	})
+}
+
+func TestMarketRuleRounding(t *testing.T) {
+	rule := &models.MarketRule{
+		ID: 1,
+		PriceIncrements: []models.PriceIncrement{
+			{LowEdge: 0, Increment: 0.0001},
+			{LowEdge: 1, Increment: 0.01},
+			{LowEdge: 100, Increment: 0.05},
+		},
+	}
+
+	increments := []struct {
+		price     float64
+		increment float64
+	}{
+		{0.5, 0.0001},
+		{0.99999, 0.0001}, // Just below a band edge
+		{1, 0.01},         // Band edges are inclusive
+		{100, 0.05},
+		{250, 0.05},
+		{-50, 0.01}, // Negative prices use the band of their absolute value
+	}
+	for _, tc := range increments {
+		if increment := rule.Increment(tc.price); increment != tc.increment {
+			t.Errorf("Increment(%v) = %v, expected %v", tc.price, increment, tc.increment)
+		}
+	}
+
+	prices := []struct {
+		price   float64
+		rounded float64
+	}{
+		{0.12346, 0.1235}, // Raw result is 0.12350000000000001
+		{1.006, 1.01},
+		{99.996, 100},
+		{100.17, 100.15},
+		{123.4567, 123.45},
+		{-1.006, -1.01},
+		{-100.17, -100.15},
+	}
+	for _, tc := range prices {
+		if rounded := rule.RoundPrice(tc.price); rounded != tc.rounded {
+			t.Errorf("RoundPrice(%v) = %v, expected %v", tc.price, rounded, tc.rounded)
+		}
+	}
+
+	// A rule without increments leaves the price untouched
+	emptyRule := &models.MarketRule{}
+	if increment := emptyRule.Increment(1.2345); increment != 0 {
+		t.Errorf("Increment(1.2345) = %v on an empty rule, expected 0", increment)
+	}
+	if rounded := emptyRule.RoundPrice(1.2345); rounded != 1.2345 {
+		t.Errorf("RoundPrice(1.2345) = %v on an empty rule, expected 1.2345", rounded)
+	}
+	if rounded := emptyRule.RoundPrice(-1.2345); rounded != -1.2345 {
+		t.Errorf("RoundPrice(-1.2345) = %v on an empty rule, expected -1.2345", rounded)
+	}
+}
+
+func TestFaConfigRoundTrip(t *testing.T) {
+	amount := 1.5
+	configs := []struct {
+		faData models.FaData
+		config *models.FaConfig
+	}{
+		{
+			faData: models.FaDataGroups,
+			config: &models.FaConfig{
+				Groups: []models.FaGroup{
+					{
+						Name:          "Group_1",
+						DefaultMethod: "NetLiq",
+						Accounts: []models.FaGroupAccount{
+							{Account: "DU0000001", Amount: &amount},
+							{Account: "DU0000002"},
+						},
+					},
+					{
+						Name:          "Group_2",
+						DefaultMethod: "AvailableEquity",
+						Accounts: []models.FaGroupAccount{
+							{Account: "DU0000003"},
+						},
+					},
+				},
+			},
+		},
+		{
+			faData: models.FaDataAliases,
+			config: &models.FaConfig{
+				Aliases: []models.FaAccountAlias{
+					{Account: "DU0000001", Alias: "Client A"},
+					{Account: "DU0000002", Alias: "Client <B> & Co"},
+				},
+			},
+		},
+	}
+	for _, tc := range configs {
+		doc, err := tc.config.EncodeXML(tc.faData)
+		if err != nil {
+			t.Errorf("unable to encode fa %s [err=%v]", tc.faData.String(), err)
+			continue
+		}
+		decoded, err := models.NewFaConfigFromXML(tc.faData, doc)
+		if err != nil {
+			t.Errorf("unable to decode fa %s [err=%v]", tc.faData.String(), err)
+			continue
+		}
+		if !reflect.DeepEqual(decoded.Groups, tc.config.Groups) || !reflect.DeepEqual(decoded.Aliases, tc.config.Aliases) {
+			t.Errorf("fa %s round trip mismatch:\n%s", tc.faData.String(), doc)
+		}
+	}
 }
 
 // -----------------------------------------------------------------------------
@@ -133,6 +448,18 @@ func testManagedAccounts(t *testing.T, client *ibkr.Client) {
 	t.Log("  Accounts: " + strings.Join(accounts, ", "))
 }
 
+func testNextOrderID(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	orderID, err := client.RequestNextOrderID(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Logf("  Next order ID: %d", orderID)
+}
+
 func testHistoricalData(t *testing.T, client *ibkr.Client) {
 	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancelCtx()
@@ -284,6 +611,821 @@ func testDepthMarketData(t *testing.T, client *ibkr.Client) {
 	}
 }
 
+func testWhatIfOrder(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	quantity := models.DecimalOne
+	limitPrice := 1.0
+	order := models.LimitOrder("BUY", &quantity, &limitPrice)
+	order.WhatIf = true
+
+	resp, err := client.PlaceOrder(ctx, models.PlaceOrderRequestOptions{
+		Contract: getContract("JPM", "SMART"),
+		Order:    order,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Detach()
+
+	for loop := true; loop; {
+		select {
+		case <-ctx.Done():
+			t.Error(ctx.Err())
+			loop = false
+
+		case evt, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + evt.String())
+		}
+	}
+}
+
+func testExerciseOptions(t *testing.T, client *ibkr.Client) {
+	// Exercise instructions are real requests, so they are only sent to an explicitly configured paper account
+	account := os.Getenv("IBKR_TEST_PAPER_ACCOUNT")
+	if len(account) == 0 {
+		t.Skip("IBKR_TEST_PAPER_ACCOUNT not set")
+	}
+	if !strings.HasPrefix(account, "D") {
+		// Paper trading account IDs start with D, i.e.: DU1234567
+		t.Skip("IBKR_TEST_PAPER_ACCOUNT is not a paper trading account")
+	}
+
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancelCtx()
+
+	// Pick an option of the farthest expiration. Lapsing an option before its last trading day is rejected by the
+	// server, so the account is not altered.
+	underlying := getContract("SPY", "SMART")
+	cdResp, err := client.RequestContractDetails(ctx, models.ContractDetailsRequestOptions{
+		Contract: underlying,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cdResp.ContractDetails) == 0 {
+		t.Error("no contract details")
+		return
+	}
+	chains, err := client.RequestOptionChainParams(ctx, underlying.Symbol, "", underlying.SecType, cdResp.ContractDetails[0].Contract.ConID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var option *models.Contract
+	for _, chain := range chains {
+		if chain.Exchange == "SMART" && len(chain.Expirations) > 0 {
+			lastExpiration := chain.Expirations[len(chain.Expirations)-1]
+			contracts := chain.Contracts(underlying, models.OptionChainFilter{
+				FirstExpiration: lastExpiration,
+				LastExpiration:  lastExpiration,
+			})
+			if len(contracts) > 0 {
+				option = contracts[0]
+			}
+			break
+		}
+	}
+	if option == nil {
+		t.Error("no option contract found")
+		return
+	}
+	t.Log("  Option: " + option.String())
+
+	resp, err := client.ExerciseOptions(ctx, option, models.OptionExerciseLapse, 1, account, false, "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Detach()
+
+	for loop := true; loop; {
+		select {
+		case <-ctx.Done():
+			t.Error(ctx.Err())
+			loop = false
+
+		case evt, ok := <-resp.Channel:
+			if !ok {
+				// The instruction is expected to be rejected
+				loop = false
+				break
+			}
+			t.Log("  " + evt.String())
+			if _, isError := evt.(*models.OrderError); isError {
+				loop = false
+			}
+		}
+	}
+}
+
+func testOpenOrders(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	openOrders, err := client.RequestAllOpenOrders(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, oo := range openOrders {
+		t.Log("  " + oo.String())
+	}
+}
+
+func testCompletedOrders(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	completedOrders, err := client.RequestCompletedOrders(ctx, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, co := range completedOrders {
+		t.Log("  " + co.String())
+	}
+}
+
+func testExecutions(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	executions, err := client.RequestExecutions(ctx, nil)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, ed := range executions {
+		t.Log("  " + ed.String())
+	}
+}
+
+func testAccountUpdates(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	accounts, err := client.RequestManagedAccounts(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(accounts) == 0 {
+		t.Error("no managed accounts")
+		return
+	}
+
+	resp, err := client.SubscribeAccountUpdates(ctx, accounts[0])
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	for loop := true; loop; {
+		select {
+		case <-ctx.Done():
+			t.Error(ctx.Err())
+			loop = false
+
+		case upd, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + upd.String())
+
+			if _, ok = upd.(*models.AccountDownloadEnd); ok {
+				for _, pi := range resp.Snapshot.Portfolio() {
+					t.Log("  " + pi.String())
+				}
+				loop = false
+			}
+		}
+	}
+}
+
+func testAccountSummary(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	summary, err := client.RequestAccountSummary(ctx, models.AccountSummaryGroupAll, []models.AccountSummaryTag{
+		models.AccountSummaryTagNetLiquidation,
+		models.AccountSummaryTagBuyingPower,
+		models.AccountSummaryTagExcessLiquidity,
+		models.AccountSummaryTagLedgerAll,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for account, tags := range summary {
+		for tag, value := range tags {
+			t.Logf("  %s: %s = %s %s", account, tag, value.Value, value.Currency)
+		}
+	}
+}
+
+func testAccountUpdatesMulti(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	accounts, err := client.RequestManagedAccounts(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(accounts) == 0 {
+		t.Error("no managed accounts")
+		return
+	}
+
+	resp, err := client.SubscribeAccountUpdatesMulti(ctx, accounts[0], "", true)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	for loop := true; loop; {
+		select {
+		case <-ctx.Done():
+			t.Error(ctx.Err())
+			loop = false
+
+		case upd, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + upd.String())
+
+			if _, ok = upd.(*models.AccountMultiEnd); ok {
+				loop = false
+			}
+		}
+	}
+}
+
+func testPositions(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	positions, err := client.RequestPositions(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, pos := range positions {
+		t.Logf("  %s", pos.String())
+	}
+}
+
+func testPnL(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	accounts, err := client.RequestManagedAccounts(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(accounts) == 0 {
+		t.Error("no managed accounts")
+		return
+	}
+
+	resp, err := client.SubscribePnL(ctx, accounts[0], "")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Error(ctx.Err())
+
+	case pnl, ok := <-resp.Channel:
+		if !ok {
+			if resp.Err() != nil {
+				t.Error(resp.Err())
+			}
+			break
+		}
+		t.Log("  " + pnl.String())
+	}
+}
+
+func testRealTimeBars(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	resp, err := client.SubscribeRealTimeBars(ctx, getContract("VOO", "SMART"), models.WhatToShowMidPoint, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	doneCh := time.After(20 * time.Second)
+	for loop := true; loop; {
+		select {
+		case <-doneCh:
+			loop = false
+
+		case bar, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + bar.String())
+		}
+	}
+}
+
+func testTickByTick(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	resp, err := client.SubscribeTickByTick(ctx, getContract("VOO", "SMART"), models.TickByTickTypeBidAsk, 0, false)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	doneCh := time.After(20 * time.Second)
+	for loop := true; loop; {
+		select {
+		case <-doneCh:
+			loop = false
+
+		case tick, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + tick.String())
+		}
+	}
+}
+
+func testHistoricalDataStream(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	resp, err := client.SubscribeHistoricalData(ctx, models.HistoricalDataRequestOptions{
+		Contract:     getContract("VOO", "SMART"),
+		Duration:     1,
+		DurationUnit: models.DurationUnitDays,
+		BarSize:      models.BarSizeOneMinute,
+		WhatToShow:   models.WhatToShowMidPoint,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	t.Logf("  Backfill bars: %d", len(resp.Bars))
+
+	doneCh := time.After(20 * time.Second)
+	for loop := true; loop; {
+		select {
+		case <-doneCh:
+			loop = false
+
+		case upd, ok := <-resp.Channel:
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				loop = false
+				break
+			}
+			t.Log("  " + upd.String())
+		}
+	}
+}
+
+func testHeadTimestamp(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	resp, err := client.RequestHeadTimestamp(ctx, models.HeadTimestampRequestOptions{
+		Contract:   getContract("JPM", "SMART"),
+		WhatToShow: models.WhatToShowTrades,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  Head timestamp: " + resp.Timestamp.Format("2006-01-02 15:04:05"))
+}
+
+func testHistogramData(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	data, err := client.RequestHistogramData(ctx, getContract("JPM", "SMART"), true, "1 week")
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, hd := range data {
+		t.Log("  " + hd.String())
+	}
+}
+
+func testScanner(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelCtx()
+
+	params, err := client.RequestScannerParameters(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  " + params.String())
+
+	sub := models.NewScannerSubscription()
+	sub.Instrument = "STK"
+	sub.LocationCode = "STK.US.MAJOR"
+	sub.ScanCode = "TOP_PERC_GAIN"
+	resp, err := client.SubscribeScanner(ctx, sub, []models.TagValue{
+		{Tag: "priceAbove", Value: "5"},
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	select {
+	case <-ctx.Done():
+		t.Error(ctx.Err())
+
+	case results, ok := <-resp.Channel:
+		if !ok {
+			if resp.Err() != nil {
+				t.Error(resp.Err())
+			}
+			break
+		}
+		for _, result := range results {
+			t.Log("  " + result.String())
+		}
+	}
+}
+
+func testNews(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancelCtx()
+
+	providers, err := client.RequestNewsProviders(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(providers) == 0 {
+		t.Log("  no news providers")
+		return
+	}
+	providerCodes := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		t.Log("  " + provider.String())
+		providerCodes = append(providerCodes, provider.Code)
+	}
+
+	cdResp, err := client.RequestContractDetails(ctx, models.ContractDetailsRequestOptions{
+		Contract: getContract("AAPL", "SMART"),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cdResp.ContractDetails) == 0 {
+		t.Error("no contract details")
+		return
+	}
+
+	resp, err := client.RequestHistoricalNews(
+		ctx, cdResp.ContractDetails[0].Contract.ConID, providerCodes, time.Now().Add(-7*24*time.Hour), time.Now(), 10,
+	)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, news := range resp.News {
+		t.Log("  " + news.String())
+	}
+	if len(resp.News) == 0 {
+		return
+	}
+
+	article, err := client.RequestNewsArticle(ctx, resp.News[0].ProviderCode, resp.News[0].ArticleID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  " + article.String())
+}
+
+func testFundamentalData(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancelCtx()
+
+	fd, err := client.RequestFundamentalData(ctx, getContract("AAPL", "SMART"), models.FundamentalReportTypeSnapshot)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  " + fd.String())
+	if fd.Snapshot != nil {
+		t.Log("  CompanyName: " + fd.Snapshot.CompanyName())
+	}
+}
+
+func testOptionChain(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancelCtx()
+
+	underlying := getContract("SPY", "SMART")
+	cdResp, err := client.RequestContractDetails(ctx, models.ContractDetailsRequestOptions{
+		Contract: underlying,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cdResp.ContractDetails) == 0 {
+		t.Error("no contract details")
+		return
+	}
+
+	chains, err := client.RequestOptionChainParams(ctx, underlying.Symbol, "", underlying.SecType, cdResp.ContractDetails[0].Contract.ConID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, chain := range chains {
+		t.Log("  " + chain.String())
+		if chain.Exchange == "SMART" && len(chain.Expirations) > 0 {
+			contracts := chain.Contracts(underlying, models.OptionChainFilter{
+				FirstExpiration: chain.Expirations[0],
+				LastExpiration:  chain.Expirations[0],
+			})
+			t.Logf("  %d contracts for %s", len(contracts), chain.Expirations[0])
+		}
+	}
+}
+
+func testOptionComputation(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancelCtx()
+
+	underlying := getContract("SPY", "SMART")
+	cdResp, err := client.RequestContractDetails(ctx, models.ContractDetailsRequestOptions{
+		Contract: underlying,
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cdResp.ContractDetails) == 0 {
+		t.Error("no contract details")
+		return
+	}
+
+	chains, err := client.RequestOptionChainParams(ctx, underlying.Symbol, "", underlying.SecType, cdResp.ContractDetails[0].Contract.ConID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	var option *models.Contract
+	for _, chain := range chains {
+		if chain.Exchange == "SMART" && len(chain.Expirations) > 0 && len(chain.Strikes) > 0 {
+			strike := chain.Strikes[len(chain.Strikes)/2]
+			contracts := chain.Contracts(underlying, models.OptionChainFilter{
+				FirstExpiration: chain.Expirations[0],
+				LastExpiration:  chain.Expirations[0],
+				MinStrike:       &strike,
+				MaxStrike:       &strike,
+				Rights:          []string{"C"},
+			})
+			if len(contracts) > 0 {
+				option = contracts[0]
+			}
+			break
+		}
+	}
+	if option == nil {
+		t.Error("no option contract found")
+		return
+	}
+	underPrice := *option.Strike
+
+	computation, err := client.CalculateOptionPrice(ctx, option, 0.2, underPrice)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  " + computation.String())
+
+	if computation.Price != nil {
+		computation, err = client.CalculateImpliedVolatility(ctx, option, *computation.Price, underPrice)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		t.Log("  " + computation.String())
+	}
+}
+
+func testMarketRule(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	cdResp, err := client.RequestContractDetails(ctx, models.ContractDetailsRequestOptions{
+		Contract: getContract("SPY", "SMART"),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(cdResp.ContractDetails) == 0 {
+		t.Error("no contract details")
+		return
+	}
+	cd := cdResp.ContractDetails[0]
+
+	ruleID, ok := cd.MarketRuleID("SMART")
+	if !ok {
+		t.Error("no market rule for SMART")
+		return
+	}
+	rule, err := client.RequestMarketRule(ctx, ruleID)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  " + rule.String())
+
+	price, err := client.RoundLimitPrice(ctx, cd, "SMART", 123.4567)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Logf("  Rounded price: %v", price)
+}
+
+func testMarketDepthExchanges(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	exchanges, err := client.RequestMarketDepthExchanges(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, exchange := range exchanges {
+		t.Log("  " + exchange.String())
+	}
+
+	resp, err := client.RequestTopMarketData(ctx, models.TopMarketDataRequestOptions{
+		Contract: getContract("SPY", "SMART"),
+	})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	defer resp.Cancel()
+
+	var params *models.TopMarketDataRequestParams
+	for params == nil {
+		select {
+		case <-ctx.Done():
+			t.Error(ctx.Err())
+			return
+
+		case _, ok := <-resp.Channel:
+			// Drain the market data so the parameters are not held back
+			if !ok {
+				if resp.Err() != nil {
+					t.Error(resp.Err())
+				}
+				return
+			}
+
+		case params = <-resp.RequestParams:
+		}
+	}
+	t.Log("  " + params.String())
+	if len(params.BBOExchange) == 0 {
+		return
+	}
+
+	components, err := client.RequestSmartComponents(ctx, params.BBOExchange)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, component := range components {
+		t.Log("  " + component.String())
+	}
+}
+
+func testAccountMetadata(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	familyCodes, err := client.RequestFamilyCodes(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, familyCode := range familyCodes {
+		t.Log("  " + familyCode.String())
+	}
+
+	tiers, err := client.RequestSoftDollarTiers(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, tier := range tiers {
+		t.Log("  " + tier.String())
+	}
+
+	whiteBrandingID, err := client.RequestUserInfo(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	t.Log("  White branding ID: " + whiteBrandingID)
+
+	if !client.IsConnected() {
+		t.Error("client disconnected after account metadata requests")
+	}
+}
+
+func testFinancialAdvisor(t *testing.T, client *ibkr.Client) {
+	ctx, cancelCtx := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancelCtx()
+
+	accounts, err := client.RequestManagedAccounts(ctx)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if len(accounts) < 2 {
+		// The server ignores FA requests from non-FA customers
+		t.Log("  Not a Financial Advisor account")
+		return
+	}
+
+	groups, err := client.RequestFA(ctx, models.FaDataGroups)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, group := range groups.Groups {
+		t.Logf("  Group %s (%s): %d account(s)", group.Name, group.DefaultMethod, len(group.Accounts))
+	}
+
+	aliases, err := client.RequestFA(ctx, models.FaDataAliases)
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	for _, alias := range aliases.Aliases {
+		t.Log("  " + alias.Account + ": " + alias.Alias)
+	}
+}
+
 func getContract(symbol string, exchange string) *models.Contract {
 	contract := models.NewContract()
 	contract.Symbol = symbol
@@ -7,10 +7,10 @@ import (
 	"fmt"
 	"math/rand/v2"
 	"net"
-	"sync/atomic"
 
 	"github.com/mxmauro/ibkr/common"
 	"github.com/mxmauro/ibkr/connection"
+	"github.com/mxmauro/ibkr/models"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
 )
 
@@ -51,6 +51,8 @@ func (c *Client) connectToServer(ctx context.Context, opts Options) error {
 	// Set up client id
 	if opts.ClientID > 0 {
 		c.clientID = opts.ClientID
+	} else if opts.UseClientIDZero {
+		c.clientID = 0
 	} else {
 		c.clientID = 1 + int32(rand.Uint32()&0x7FFFFFFE)
 	}
@@ -62,8 +64,8 @@ func (c *Client) connectToServer(ctx context.Context, opts Options) error {
 		return err
 	}
 
-	// Skip initial incoming messages until we receive the next valid request ID
-	err = c.waitUntilNextReqID(ctx, conn)
+	// Skip initial incoming messages until we receive the next valid order ID
+	err = c.waitUntilNextValidID(ctx, conn)
 	if err != nil {
 		return err
 	}
@@ -139,8 +141,8 @@ func (c *Client) startApi(conn *connection.Connection, opts Options) error {
 	return conn.Send(msgEnc.Bytes())
 }
 
-// This function ignores all the initial incoming messages until we receive the next valid request ID.
-func (c *Client) waitUntilNextReqID(ctx context.Context, conn *connection.Connection) error {
+// This function ignores all the initial incoming messages until we receive the next valid order ID.
+func (c *Client) waitUntilNextValidID(ctx context.Context, conn *connection.Connection) error {
 	var msgID uint32
 	var usingProtobuf bool
 
@@ -159,16 +161,17 @@ func (c *Client) waitUntilNextReqID(ctx context.Context, conn *connection.Connec
 				return errors.New("unsupported NEXT_VALID_ID message with protobuf")
 			}
 			msgDec := message.NewDecoder(msg[4:])
-			reqID := msgDec.Int32()
+			msgDec.Skip() // version
+			orderID := models.OrderID(msgDec.Int32())
 			if msgDec.Err() != nil {
 				return msgDec.Err()
 			}
-			if reqID < 1 {
-				reqID = 1
+			if orderID < 1 {
+				orderID = 1
 			}
 
 			// Done
-			atomic.StoreInt32(&c.nextValidReqID, reqID)
+			c.orderIDs.seed(orderID)
 			return nil
 		}
 	}
@@ -260,6 +263,23 @@ func (c *Client) sendRequest(msg []byte, req *Request) error {
 	return err
 }
 
+// registerRequest adds a request which is fed by unsolicited messages, so nothing is sent to the server.
+func (c *Client) registerRequest(req *Request) error {
+	// Do we have a connection?
+	c.connMtx.Lock()
+	defer c.connMtx.Unlock()
+
+	if c.conn == nil {
+		return c.getConnError()
+	}
+
+	// Add to the active requests map
+	c.reqMgr.addRequest(req)
+
+	// Done
+	return nil
+}
+
 func (c *Client) getConnError() error {
 	v := c.connErrHolder.Load()
 	if v == nil {
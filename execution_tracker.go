@@ -0,0 +1,219 @@
+package ibkr
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mxmauro/ibkr/common"
+	"github.com/mxmauro/ibkr/models"
+)
+
+// -----------------------------------------------------------------------------
+
+// executionTracker keeps the executions waiting for their commission and fees report. Reports do not carry a
+// request ID, so they are matched by execution ID.
+type executionTracker struct {
+	mtx           sync.Mutex
+	pending       map[string][]*pendingExecution
+	endedRequests map[int32]struct{}
+	fillsActive   bool
+	reportTimeout time.Duration
+}
+
+type pendingExecution struct {
+	reqID   int32 // -1 for live fills
+	details *models.ExecutionDetails
+}
+
+const defaultCommissionReportTimeout = 5 * time.Second
+
+// -----------------------------------------------------------------------------
+
+func (c *Client) initExecutionTracker() {
+	c.execTracker = executionTracker{
+		mtx:           sync.Mutex{},
+		pending:       make(map[string][]*pendingExecution),
+		endedRequests: make(map[int32]struct{}),
+	}
+}
+
+// startFills enables tracking of live fills and returns false if a fills subscription is already active.
+func (t *executionTracker) startFills(reportTimeout time.Duration) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if t.fillsActive {
+		return false
+	}
+	t.fillsActive = true
+	t.reportTimeout = reportTimeout
+	return true
+}
+
+func (t *executionTracker) stopFills() {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.fillsActive = false
+}
+
+// trackRequestExecution keeps the execution received as a response of a RequestExecutions call so the commission
+// report can be attached later.
+func (t *executionTracker) trackRequestExecution(reqID int32, ed *models.ExecutionDetails) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	t.add(&pendingExecution{
+		reqID:   reqID,
+		details: ed,
+	})
+}
+
+// trackLiveExecution keeps a live fill until its commission report arrives or the timeout elapses. Returns false if
+// no fills subscription is active.
+func (c *Client) trackLiveExecution(ed *models.ExecutionDetails) bool {
+	t := &c.execTracker
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if !t.fillsActive {
+		return false
+	}
+
+	pe := &pendingExecution{
+		reqID:   -1,
+		details: ed,
+	}
+	t.add(pe)
+
+	// Emit the fill without the report if it does not arrive in time
+	time.AfterFunc(t.reportTimeout, func() {
+		if t.remove(pe) {
+			c.emitFill(pe.details)
+		}
+	})
+
+	// Done
+	return true
+}
+
+// attachReport sets the commission report on all the pending executions with the same execution ID and returns the
+// live fills that must be emitted and the ended requests that are no longer waiting for reports.
+func (t *executionTracker) attachReport(report *models.CommissionAndFeesReport) ([]*models.ExecutionDetails, []int32) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	l, ok := t.pending[report.ExecID]
+	if !ok {
+		return nil, nil
+	}
+	delete(t.pending, report.ExecID)
+
+	fills := make([]*models.ExecutionDetails, 0, len(l))
+	completedReqIDs := make([]int32, 0)
+	for _, pe := range l {
+		pe.details.CommissionAndFeesReport = report
+		if pe.reqID < 0 {
+			fills = append(fills, pe.details)
+		} else if _, ended := t.endedRequests[pe.reqID]; ended && !t.isRequestPending(pe.reqID) {
+			delete(t.endedRequests, pe.reqID)
+			completedReqIDs = append(completedReqIDs, pe.reqID)
+		}
+	}
+	return fills, completedReqIDs
+}
+
+// endRequest marks all the executions of the given request as received and returns true if some of them are still
+// waiting for their commission report.
+func (t *executionTracker) endRequest(reqID int32) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	if !t.isRequestPending(reqID) {
+		return false
+	}
+	t.endedRequests[reqID] = struct{}{}
+	return true
+}
+
+// untrackRequest stops waiting for the reports of the executions returned by the given request.
+func (t *executionTracker) untrackRequest(reqID int32) {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	delete(t.endedRequests, reqID)
+
+	for execID, l := range t.pending {
+		filtered := l[:0]
+		for _, pe := range l {
+			if pe.reqID != reqID {
+				filtered = append(filtered, pe)
+			}
+		}
+		if len(filtered) > 0 {
+			t.pending[execID] = filtered
+		} else {
+			delete(t.pending, execID)
+		}
+	}
+}
+
+func (t *executionTracker) isRequestPending(reqID int32) bool {
+	for _, l := range t.pending {
+		for _, pe := range l {
+			if pe.reqID == reqID {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (t *executionTracker) add(pe *pendingExecution) {
+	execID := pe.details.Execution.ExecID
+	t.pending[execID] = append(t.pending[execID], pe)
+}
+
+func (t *executionTracker) remove(pe *pendingExecution) bool {
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+
+	execID := pe.details.Execution.ExecID
+	l := t.pending[execID]
+	for idx := range l {
+		if l[idx] == pe {
+			l = append(l[:idx], l[idx+1:]...)
+			if len(l) > 0 {
+				t.pending[execID] = l
+			} else {
+				delete(t.pending, execID)
+			}
+			return true
+		}
+	}
+	return false
+}
+
+// completeExecutionsRequest stops waiting for the commission reports of the given RequestExecutions call and
+// completes it.
+func (c *Client) completeExecutionsRequest(reqID int32) {
+	c.execTracker.untrackRequest(reqID)
+
+	c.reqMgr.withRequestWithID(reqID, func(_ interface{}) (bool, error) {
+		// Done
+		return true, nil
+	})
+}
+
+func (c *Client) emitFill(ed *models.ExecutionDetails) {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_EXECUTIONS, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.FillsResponse)
+
+		// Notify
+		resp.Channel <- ed
+
+		// Done
+		return false, nil
+	})
+}
@@ -0,0 +1,54 @@
+package ibkr
+
+import (
+	"sync"
+	"time"
+
+	"github.com/mxmauro/ibkr/models"
+)
+
+// -----------------------------------------------------------------------------
+
+// historicalDataStream is the response holder of a historical data subscription. Updates do not say if they start
+// a new bar, so the date of the last bar is tracked to tell it.
+type historicalDataStream struct {
+	resp             *models.HistoricalDataStreamResponse
+	lastBarDate      time.Time
+	backfillDoneCh   chan struct{}
+	backfillDoneOnce sync.Once
+}
+
+// -----------------------------------------------------------------------------
+
+func newHistoricalDataStream(resp *models.HistoricalDataStreamResponse) *historicalDataStream {
+	return &historicalDataStream{
+		resp:           resp,
+		backfillDoneCh: make(chan struct{}),
+	}
+}
+
+func (s *historicalDataStream) addBackfillBars(bars []models.HistoricalDataBar) {
+	s.resp.Bars = append(s.resp.Bars, bars...)
+	if len(bars) > 0 {
+		s.lastBarDate = bars[len(bars)-1].Date
+	}
+}
+
+func (s *historicalDataStream) endBackfill() {
+	s.backfillDoneOnce.Do(func() {
+		close(s.backfillDoneCh)
+	})
+}
+
+func (s *historicalDataStream) update(bar models.HistoricalDataBar) {
+	upd := models.HistoricalDataUpdate{
+		Bar:      bar,
+		IsNewBar: bar.Date.After(s.lastBarDate),
+	}
+	if upd.IsNewBar {
+		s.lastBarDate = bar.Date
+	}
+
+	// Notify
+	s.resp.Channel <- upd
+}
@@ -52,8 +52,18 @@ func (c *Client) processIncomingMessage(msg []byte) error {
 			return c.processTickGenericProtobuf(msgDec)
 		case common.TICK_STRING:
 			return c.processTickStringProtobuf(msgDec)
+		case common.ORDER_STATUS:
+			return c.processOrderStatusProtobuf(msgDec)
 		case common.ERR_MSG:
 			return c.processErrorMessageProtobuf(msgDec)
+		case common.OPEN_ORDER:
+			return c.processOpenOrderProtobuf(msgDec)
+		case common.ACCT_VALUE:
+			return c.processAcctValueProtobuf(msgDec)
+		case common.PORTFOLIO_VALUE:
+			return c.processPortfolioValueProtobuf(msgDec)
+		case common.ACCT_UPDATE_TIME:
+			return c.processAcctUpdateTimeProtobuf(msgDec)
 		case common.CONTRACT_DATA:
 			return c.processContractDataProtobuf(msgDec)
 		case common.BOND_CONTRACT_DATA:
@@ -66,24 +76,58 @@ func (c *Client) processIncomingMessage(msg []byte) error {
 			return c.processManagedAccountsProtobuf(msgDec)
 		case common.HISTORICAL_DATA:
 			return c.processHistoricalDataProtobuf(msgDec)
+		case common.REAL_TIME_BARS:
+			return c.processRealTimeBarsProtobuf(msgDec)
 		case common.CONTRACT_DATA_END:
 			return c.processContractDataEndProtobuf(msgDec)
+		case common.OPEN_ORDER_END:
+			return c.processOpenOrdersEndProtobuf(msgDec)
+		case common.ACCT_DOWNLOAD_END:
+			return c.processAcctDownloadEndProtobuf(msgDec)
+		case common.ACCOUNT_SUMMARY:
+			return c.processAccountSummaryProtobuf(msgDec)
+		case common.ACCOUNT_SUMMARY_END:
+			return c.processAccountSummaryEndProtobuf(msgDec)
+		case common.POSITION_DATA:
+			return c.processPositionDataProtobuf(msgDec)
+		case common.POSITION_END:
+			return c.processPositionEndProtobuf(msgDec)
+		case common.POSITION_MULTI:
+			return c.processPositionMultiProtobuf(msgDec)
+		case common.POSITION_MULTI_END:
+			return c.processPositionMultiEndProtobuf(msgDec)
+		case common.ACCOUNT_UPDATE_MULTI:
+			return c.processAccountUpdateMultiProtobuf(msgDec)
+		case common.ACCOUNT_UPDATE_MULTI_END:
+			return c.processAccountUpdateMultiEndProtobuf(msgDec)
+		case common.EXECUTION_DATA:
+			return c.processExecutionDetailsProtobuf(msgDec)
+		case common.EXECUTION_DATA_END:
+			return c.processExecutionDetailsEndProtobuf(msgDec)
+		case common.COMPLETED_ORDER:
+			return c.processCompletedOrderProtobuf(msgDec)
+		case common.COMPLETED_ORDERS_END:
+			return c.processCompletedOrdersEndProtobuf(msgDec)
 		case common.TICK_SNAPSHOT_END:
 			return c.processTickSnapshotEndProtobuf(msgDec)
 		case common.MARKET_DATA_TYPE:
 			return nil // Ignore this message. We don't use the ticker callback.
 		case common.TICK_REQ_PARAMS:
-			return nil // Ignore this message. We don't use the tick request parameters.
+			return c.processTickReqParamsProtobuf(msgDec)
 		case common.HEAD_TIMESTAMP:
 			return c.processHeadTimestampProtobuf(msgDec)
+		case common.HISTOGRAM_DATA:
+			return c.processHistogramDataProtobuf(msgDec)
 		case common.HISTORICAL_DATA_UPDATE:
-			return nil // KeepUpToDate is always false, ignore the message
+			return c.processHistoricalDataUpdateProtobuf(msgDec)
 		case common.HISTORICAL_TICKS:
 			return c.processHistoricalTicksProtobuf(msgDec)
 		case common.HISTORICAL_TICKS_BID_ASK:
 			return c.processHistoricalTicksBidAskProtobuf(msgDec)
 		case common.HISTORICAL_TICKS_LAST:
 			return c.processHistoricalTicksLastProtobuf(msgDec)
+		case common.TICK_BY_TICK:
+			return c.processTickByTickDataProtobuf(msgDec)
 		case common.HISTORICAL_DATA_END:
 			return c.processHistoricalDataEndProtobuf(msgDec)
 		}
@@ -106,39 +150,29 @@ func (c *Client) processIncomingMessage(msg []byte) error {
 		case common.TICK_EFP:
 			return c.processTickEfpMsg(msgDec)
 
-			/*
-				case ORDER_STATUS:
-					return c.processOrderStatusMsg(msgDec)
-			*/
-
+		case common.ORDER_STATUS:
+			return c.processOrderStatusMsg(msgDec)
 		case common.ERR_MSG:
 			return c.processErrorMessageMsg(msgDec)
-			/*
-				case OPEN_ORDER:
-					return c.processOpenOrderMsg(msgDec)
-				case ACCT_VALUE:
-					return c.processAcctValueMsg(msgDec)
-				case PORTFOLIO_VALUE:
-					return c.processPortfolioValueMsg(msgDec)
-				case ACCT_UPDATE_TIME:
-					return c.processAcctUpdateTimeMsg(msgDec)
-			*/
+		case common.OPEN_ORDER:
+			return c.processOpenOrderMsg(msgDec)
+		case common.ACCT_VALUE:
+			return c.processAcctValueMsg(msgDec)
+		case common.PORTFOLIO_VALUE:
+			return c.processPortfolioValueMsg(msgDec)
+		case common.ACCT_UPDATE_TIME:
+			return c.processAcctUpdateTimeMsg(msgDec)
 
 		case common.NEXT_VALID_ID:
-			return nil // Ignore this message
+			return c.processNextValidIdMsg(msgDec)
 		case common.CONTRACT_DATA:
 			return c.processContractDataMsg(msgDec)
 		case common.BOND_CONTRACT_DATA:
 			return c.processBondContractDataMsg(msgDec)
-
-			/*
-				case EXECUTION_DATA:
-					if useProtoBuf {
-						return c.processExecutionDetailsMsgProtoBuf(msgDec)
-					} else {
-						return c.processExecutionDetailsMsg(msgDec)
-					}
-			*/
+		case common.EXECUTION_DATA:
+			return c.processExecutionDetailsMsg(msgDec)
+		case common.NEWS_BULLETINS:
+			return c.processNewsBulletinsMsg(msgDec)
 
 		case common.MARKET_DEPTH:
 			return c.processMarketDepthMsg(msgDec)
@@ -146,64 +180,49 @@ func (c *Client) processIncomingMessage(msg []byte) error {
 			return c.processMarketDepthL2Msg(msgDec)
 		case common.MANAGED_ACCTS:
 			return c.processManagedAccountsMsg(msgDec)
-
-			/*
-				case RECEIVE_FA:
-					return c.processReceiveFaMsg(msgDec)
-			*/
+		case common.RECEIVE_FA:
+			return c.processReceiveFaMsg(msgDec)
 
 		case common.HISTORICAL_DATA:
 			return c.processHistoricalDataMsg(msgDec)
-
-			/*
-				case SCANNER_DATA:
-					return c.processScannerDataMsg(msgDec)
-				case SCANNER_PARAMETERS:
-					return c.processScannerParametersMsg(msgDec)
-			*/
-
+		case common.SCANNER_DATA:
+			return c.processScannerDataMsg(msgDec)
+		case common.SCANNER_PARAMETERS:
+			return c.processScannerParametersMsg(msgDec)
 		case common.CURRENT_TIME:
 			return nil // Ignore this message. We use the one having milliseconds
-			/*
-				case REAL_TIME_BARS:
-					return c.processRealTimeBarsMsg(msgDec)
-				case FUNDAMENTAL_DATA:
-					return c.processFundamentalDataMsg(msgDec)
-			*/
+		case common.REAL_TIME_BARS:
+			return c.processRealTimeBarsMsg(msgDec)
+		case common.FUNDAMENTAL_DATA:
+			return c.processFundamentalDataMsg(msgDec)
 
 		case common.CONTRACT_DATA_END:
 			return c.processContractDataEndMsg(msgDec)
+		case common.OPEN_ORDER_END:
+			return c.processOpenOrdersEndMsg(msgDec)
+		case common.ACCT_DOWNLOAD_END:
+			return c.processAcctDownloadEndMsg(msgDec)
+		case common.EXECUTION_DATA_END:
+			return c.processExecutionDetailsEndMsg(msgDec)
 			/*
-				case common.OPEN_ORDER_END:
-					return c.processOpenOrdersEndMsg(msgDec)
-
-					/*
-						case ACCT_DOWNLOAD_END:
-							return c.processAcctDownloadEndMsg(msgDec)
-						case EXECUTION_DATA_END:
-							if useProtoBuf {
-								return c.processExecutionDetailsEndMsgProtoBuf(msgDec)
-							} else {
-								return c.processExecutionDetailsEndMsg(msgDec)
-							}
-						case DELTA_NEUTRAL_VALIDATION:
-							return c.processDeltaNeutralValidationMsg(msgDec)
+				case DELTA_NEUTRAL_VALIDATION:
+					return c.processDeltaNeutralValidationMsg(msgDec)
 			*/
 		case common.TICK_SNAPSHOT_END:
 			return c.processTickSnapshotEndMsg(msgDec)
 		case common.MARKET_DATA_TYPE:
 			return nil // Ignore this message. We don't use the ticker callback.
+		case common.COMMISSION_AND_FEES_REPORT:
+			return c.processCommissionAndFeesReportMsg(msgDec)
+		case common.POSITION_DATA:
+			return c.processPositionDataMsg(msgDec)
+		case common.POSITION_END:
+			return c.processPositionEndMsg(msgDec)
+		case common.ACCOUNT_SUMMARY:
+			return c.processAccountSummaryMsg(msgDec)
+		case common.ACCOUNT_SUMMARY_END:
+			return c.processAccountSummaryEndMsg(msgDec)
 			/*
-				case COMMISSION_AND_FEES_REPORT:
-					return c.processCommissionAndFeesReportMsg(msgDec)
-				case POSITION_DATA:
-					return c.processPositionDataMsg(msgDec)
-				case POSITION_END:
-					return c.processPositionEndMsg(msgDec)
-				case ACCOUNT_SUMMARY:
-					return c.processAccountSummaryMsg(msgDec)
-				case ACCOUNT_SUMMARY_END:
-					return c.processAccountSummaryEndMsg(msgDec)
 				case VERIFY_MESSAGE_API:
 					return c.processVerifyMessageApiMsg(msgDec)
 				case VERIFY_COMPLETED:
@@ -216,90 +235,88 @@ func (c *Client) processIncomingMessage(msg []byte) error {
 					return c.processVerifyAndAuthMessageApiMsg(msgDec)
 				case VERIFY_AND_AUTH_COMPLETED:
 					return c.processVerifyAndAuthCompletedMsg(msgDec)
-				case POSITION_MULTI:
-					return c.processPositionMultiMsg(msgDec)
-				case POSITION_MULTI_END:
-					return c.processPositionMultiEndMsg(msgDec)
-				case ACCOUNT_UPDATE_MULTI:
-					return c.processAccountUpdateMultiMsg(msgDec)
-				case ACCOUNT_UPDATE_MULTI_END:
-					return c.processAccountUpdateMultiEndMsg(msgDec)
-				case SECURITY_DEFINITION_OPTION_PARAMETER:
-					return c.processSecurityDefinitionOptionalParameterMsg(msgDec)
-				case SECURITY_DEFINITION_OPTION_PARAMETER_END:
-					return c.processSecurityDefinitionOptionalParameterEndMsg(msgDec)
-				case SOFT_DOLLAR_TIERS:
-					return c.processSoftDollarTiersMsg(msgDec)
-				case FAMILY_CODES:
-					return c.processFamilyCodesMsg(msgDec)
-				case SMART_COMPONENTS:
-					return c.processSmartComponentsMsg(msgDec)
 			*/
+		case common.POSITION_MULTI:
+			return c.processPositionMultiMsg(msgDec)
+		case common.POSITION_MULTI_END:
+			return c.processPositionMultiEndMsg(msgDec)
+		case common.ACCOUNT_UPDATE_MULTI:
+			return c.processAccountUpdateMultiMsg(msgDec)
+		case common.ACCOUNT_UPDATE_MULTI_END:
+			return c.processAccountUpdateMultiEndMsg(msgDec)
+		case common.SECURITY_DEFINITION_OPTION_PARAMETER:
+			return c.processSecurityDefinitionOptionalParameterMsg(msgDec)
+		case common.SECURITY_DEFINITION_OPTION_PARAMETER_END:
+			return c.processSecurityDefinitionOptionalParameterEndMsg(msgDec)
+		case common.SOFT_DOLLAR_TIERS:
+			return c.processSoftDollarTiersMsg(msgDec)
+		case common.FAMILY_CODES:
+			return c.processFamilyCodesMsg(msgDec)
+		case common.SMART_COMPONENTS:
+			return c.processSmartComponentsMsg(msgDec)
 
 		case common.TICK_REQ_PARAMS:
-			return nil // Ignore this message. We don't use the tick request parameters.
+			return c.processTickReqParamsMsg(msgDec)
 		case common.SYMBOL_SAMPLES:
 			return c.processSymbolSamplesMsg(msgDec)
-			/*
-				case MKT_DEPTH_EXCHANGES:
-					return c.processMktDepthExchangesMsg(msgDec)
-				case TICK_NEWS:
-					return c.processTickNewsMsg(msgDec)
-				case NEWS_PROVIDERS:
-					return c.processNewsProvidersMsg(msgDec)
-				case NEWS_ARTICLE:
-					return c.processNewsArticleMsg(msgDec)
-				case HISTORICAL_NEWS:
-					return c.processHistoricalNewsMsg(msgDec)
-				case HISTORICAL_NEWS_END:
-					return c.processHistoricalNewsEndMsg(msgDec)
-			*/
+		case common.MKT_DEPTH_EXCHANGES:
+			return c.processMktDepthExchangesMsg(msgDec)
+		case common.TICK_NEWS:
+			return c.processTickNewsMsg(msgDec)
+		case common.NEWS_PROVIDERS:
+			return c.processNewsProvidersMsg(msgDec)
+		case common.NEWS_ARTICLE:
+			return c.processNewsArticleMsg(msgDec)
+		case common.HISTORICAL_NEWS:
+			return c.processHistoricalNewsMsg(msgDec)
+		case common.HISTORICAL_NEWS_END:
+			return c.processHistoricalNewsEndMsg(msgDec)
 		case common.HEAD_TIMESTAMP:
 			return c.processHeadTimestampMsg(msgDec)
-			/*
-				case HISTOGRAM_DATA:
-					return c.processHistogramDataMsg(msgDec)
-			*/
+		case common.HISTOGRAM_DATA:
+			return c.processHistogramDataMsg(msgDec)
 		case common.HISTORICAL_DATA_UPDATE:
-			return nil // KeepUpToDate is always false, ignore the message
+			return c.processHistoricalDataUpdateMsg(msgDec)
 			/*
 				case REROUTE_MKT_DATA_REQ:
 					return c.processRerouteMktDataReqMsg(msgDec)
 				case REROUTE_MKT_DEPTH_REQ:
 					return c.processRerouteMktDepthReqMsg(msgDec)
-				case MARKET_RULE:
-					return c.processMarketRuleMsg(msgDec)
-				case PNL:
-					return c.processPnLMsg(msgDec)
-				case PNL_SINGLE:
-					return c.processPnLSingleMsg(msgDec)
 			*/
+		case common.MARKET_RULE:
+			return c.processMarketRuleMsg(msgDec)
+		case common.PNL:
+			return c.processPnLMsg(msgDec)
+		case common.PNL_SINGLE:
+			return c.processPnLSingleMsg(msgDec)
 		case common.HISTORICAL_TICKS:
 			return c.processHistoricalTicksMsg(msgDec)
 		case common.HISTORICAL_TICKS_BID_ASK:
 			return c.processHistoricalTicksBidAskMsg(msgDec)
 		case common.HISTORICAL_TICKS_LAST:
 			return c.processHistoricalTicksLastMsg(msgDec)
+		case common.TICK_BY_TICK:
+			return c.processTickByTickDataMsg(msgDec)
 			/*
-				case TICK_BY_TICK:
-					return c.processTickByTickDataMsg(msgDec)
 				case ORDER_BOUND:
 					return c.processOrderBoundMsg(msgDec)
-				case COMPLETED_ORDER:
-					return c.processCompletedOrderMsg(msgDec)
-				case COMPLETED_ORDERS_END:
-					return c.processCompletedOrdersEndMsg(msgDec)
-				case REPLACE_FA_END:
-					return c.processReplaceFAEndMsg(msgDec)
+			*/
+		case common.COMPLETED_ORDER:
+			return c.processCompletedOrderMsg(msgDec)
+		case common.COMPLETED_ORDERS_END:
+			return c.processCompletedOrdersEndMsg(msgDec)
+		case common.REPLACE_FA_END:
+			return c.processReplaceFAEndMsg(msgDec)
+			/*
 				case WSH_META_DATA:
 					return c.processWshMetaData(msgDec)
 				case WSH_EVENT_DATA:
 					return c.processWshEventData(msgDec)
 				case HISTORICAL_SCHEDULE:
 					return c.processHistoricalSchedule(msgDec)
-				case USER_INFO:
-					return c.processUserInfo(msgDec)
 			*/
+		case common.USER_INFO:
+			return c.processUserInfoMsg(msgDec)
 		case common.HISTORICAL_DATA_END:
 			return c.processHistoricalDataEndMsg(msgDec)
 		case common.CURRENT_TIME_IN_MILLIS:
@@ -353,7 +370,11 @@ func (c *Client) processTickPriceCommon(
 	tickerID int32, tickType models.TickType, price float64, size models.Decimal, attrMask int32,
 ) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
 		// Notify
 		data := models.NewTopMarketDataPrice(tickType)
@@ -422,7 +443,11 @@ func (c *Client) processTickSizeProtobuf(msgDec *protofmt.Decoder) error {
 
 func (c *Client) processTickSizeCommon(tickerID int32, tickType models.TickType, size models.Decimal) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
 		// Notify
 		data := models.NewTopMarketDataSize(tickType)
@@ -450,17 +475,13 @@ func (c *Client) processTickOptionComputationMsg(msgDec *message.Decoder) error
 	if delta != nil && utils.EqualFloat(*delta, -2) { // -2 is the "not computed" indicator
 		delta = nil
 	}
-	var price *float64
-	var pvDividend *float64
-	if tickType == models.TickTypeModelOptionComputation || tickType == models.TickTypeDelayedModelOptionComputation {
-		price = msgDec.FloatMax()
-		if price != nil && utils.EqualFloat(*price, -1) { // -1 is the "not computed" indicator
-			price = nil
-		}
-		pvDividend = msgDec.FloatMax()
-		if pvDividend != nil && utils.EqualFloat(*pvDividend, -1) { // -1 is the "not computed" indicator
-			pvDividend = nil
-		}
+	price := msgDec.FloatMax()
+	if price != nil && utils.EqualFloat(*price, -1) { // -1 is the "not computed" indicator
+		price = nil
+	}
+	pvDividend := msgDec.FloatMax()
+	if pvDividend != nil && utils.EqualFloat(*pvDividend, -1) { // -1 is the "not computed" indicator
+		pvDividend = nil
 	}
 	gamma := msgDec.FloatMax()
 	if gamma != nil && utils.EqualFloat(*gamma, -2) { // -2 is the "not yet computed" indicator
@@ -503,17 +524,13 @@ func (c *Client) processTickOptionComputationProtobuf(msgDec *protofmt.Decoder)
 	if delta != nil && utils.EqualFloat(*delta, -2) { // -2 is the "not computed" indicator
 		delta = nil
 	}
-	var price *float64
-	var pvDividend *float64
-	if tickType == models.TickTypeModelOptionComputation || tickType == models.TickTypeDelayedModelOptionComputation {
-		price = msgDec.FloatMax(pb.OptPrice)
-		if price != nil && utils.EqualFloat(*price, -1) { // -1 is the "not computed" indicator
-			price = nil
-		}
-		pvDividend = msgDec.FloatMax(pb.PvDividend)
-		if pvDividend != nil && utils.EqualFloat(*pvDividend, -1) { // -1 is the "not computed" indicator
-			pvDividend = nil
-		}
+	price := msgDec.FloatMax(pb.OptPrice)
+	if price != nil && utils.EqualFloat(*price, -1) { // -1 is the "not computed" indicator
+		price = nil
+	}
+	pvDividend := msgDec.FloatMax(pb.PvDividend)
+	if pvDividend != nil && utils.EqualFloat(*pvDividend, -1) { // -1 is the "not computed" indicator
+		pvDividend = nil
 	}
 	gamma := msgDec.FloatMax(pb.Gamma)
 	if gamma != nil && utils.EqualFloat(*gamma, -2) { // -2 is the "not yet computed" indicator
@@ -546,9 +563,6 @@ func (c *Client) processTickOptionComputationCommon(
 	pvDividend *float64, gamma *float64, vega *float64, theta *float64, undPrice *float64,
 ) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
-
-		// Notify
 		data := models.NewTopMarketDataOptionComputation(tickType)
 		data.IsPriceBased = tickAttrib != 0
 		data.ImpliedVolatility = impliedVol
@@ -559,7 +573,18 @@ func (c *Client) processTickOptionComputationCommon(
 		data.Vega = vega
 		data.Theta = theta
 		data.UnderlyingPrice = undPrice
-		resp.Channel <- data
+
+		switch resp := _resp.(type) {
+		case *models.TopMarketDataResponse:
+			// Notify
+			resp.Channel <- data
+
+		case *models.OptionComputationResponse:
+			resp.Computation = data
+
+			// Done
+			return true, nil
+		}
 
 		// Done
 		return false, nil
@@ -597,7 +622,11 @@ func (c *Client) processTickGenericProtobuf(msgDec *protofmt.Decoder) error {
 
 func (c *Client) processTickGenericCommon(tickerID int32, tickType models.TickType, value float64) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
 		// Notify
 		data := models.NewTopMarketDataGeneric(tickType)
@@ -670,7 +699,11 @@ func (c *Client) processTickStringProtobuf(msgDec *protofmt.Decoder) error {
 
 func (c *Client) processTickStringCommon(tickerID int32, tickType models.TickType, value string, ts time.Time) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
 		switch tickType {
 		case models.TickTypeLastTimestamp:
@@ -727,7 +760,11 @@ func (c *Client) processTickEfpCommon(
 	dividendsToLastTradeDate float64,
 ) error {
 	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.TopMarketDataResponse)
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
 		// Notify
 		data := models.NewTopMarketDataEFP(tickType)
@@ -748,33 +785,62 @@ func (c *Client) processTickEfpCommon(
 	return nil
 }
 
-/*
-func (c *Client) processOrderStatusMsg(msgDec *utils.Decoder) error {
+func (c *Client) processOrderStatusMsg(msgDec *message.Decoder) error {
+	osu := models.NewOrderStatusUpdateFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	if d.serverVersion < MIN_SERVER_VER_MARKET_CAP_PRICE {
-		msgDec.decode() // version
+	// Done
+	return c.processOrderStatusCommon(osu)
+}
+
+func (c *Client) processOrderStatusProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.OrderStatus{}
+	msgDec.Unmarshal(&pb)
+	osu := models.NewOrderStatusUpdateFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	orderID := msgDec.decodeInt64()
-	status := msgDec.decodeString()
-	filled := msgDec.decodeDecimal()
-	remaining := msgDec.decodeDecimal()
-	avgFilledPrice := msgDec.decodeFloat64()
+	// Done
+	return c.processOrderStatusCommon(osu)
+}
+
+func (c *Client) processOrderStatusCommon(osu *models.OrderStatusUpdate) error {
+	// Ignore orders not placed by this client
+	if !c.isOwnOrder(osu.ClientID) {
+		return nil
+	}
 
-	permID := msgDec.decodeInt64()          // ver 2 field
-	parentID := msgDec.decodeInt64()        // ver 3 field
-	lastFillPrice := msgDec.decodeFloat64() // ver 4 field
-	clientID := msgDec.decodeInt64()        // ver 5 field
-	whyHeld := msgDec.decodeString()        // ver 6 field
+	found := false
+	if osu.OrderID > 0 {
+		found = c.reqMgr.withOrder(osu.OrderID, func(_resp interface{}) (bool, error) {
+			// Notify
+			if ch, ok := orderEventsChannel(_resp); ok {
+				ch <- osu
+			}
 
-	mktCapPrice := 0.0
-	if d.serverVersion >= MIN_SERVER_VER_MARKET_CAP_PRICE {
-		mktCapPrice = msgDec.decodeFloat64()
+			// Done
+			return osu.Status.IsFinal(), nil
+		})
+	}
+	if !found {
+		c.processAutoOpenOrderEvent(osu)
 	}
 
-	d.wrapper.OrderStatus(orderID, status, filled, remaining, avgFilledPrice, permID, parentID, lastFillPrice, clientID, whyHeld, mktCapPrice)
+	// Done
+	return nil
+}
+
+// isOwnOrder returns true if the order with the given client ID belongs to this client. When connected as client 0,
+// the orders entered manually in TWS are also accepted so they can reach the auto open orders binding.
+func (c *Client) isOwnOrder(clientID int32) bool {
+	if clientID == c.clientID {
+		return true
+	}
+	return c.clientID == 0 && clientID < 0
 }
-*/
 
 func (c *Client) processErrorMessageMsg(msgDec *message.Decoder) error {
 	// Get the optional originating request ID
@@ -832,10 +898,32 @@ func (c *Client) processErrorMessageCommon(
 
 	// Process the response
 	if reqID > 0 {
-		c.reqMgr.withRequestWithID(reqID, func(_ interface{}) (bool, error) {
+		found := c.reqMgr.withOrder(models.OrderID(reqID), func(_resp interface{}) (bool, error) {
+			// Notify
+			if ch, ok := orderEventsChannel(_resp); ok {
+				ch <- &models.OrderError{
+					Timestamp:               ts,
+					Code:                    code,
+					Message:                 errMsg,
+					AdvancedOrderRejectJson: advancedOrderRejectJson,
+				}
+			}
+
+			// Rejections are not followed by a status update so they end the order tracking. Other errors and
+			// warnings are.
+			if !isFinalOrderError(code) {
+				return false, nil
+			}
+
 			// Done
 			return false, newRequestError(ts, code, errMsg, advancedOrderRejectJson)
 		})
+		if !found {
+			c.reqMgr.withRequestWithID(reqID, func(_ interface{}) (bool, error) {
+				// Done
+				return false, newRequestError(ts, code, errMsg, advancedOrderRejectJson)
+			})
+		}
 	} else {
 		// Notify
 		if c.eventsHandler != nil {
@@ -847,311 +935,961 @@ func (c *Client) processErrorMessageCommon(
 	return nil
 }
 
-/*
-func (c *Client) processOpenOrderMsg(msgDec *utils.Decoder) error {
+func (c *Client) processOpenOrderMsg(msgDec *message.Decoder) error {
+	oo := models.NewOpenOrderFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-		order := NewOrder()
-		contract := NewContract()
-		orderState := NewOrderState()
+	// Done
+	return c.processOpenOrderCommon(oo)
+}
 
-		version := d.serverVersion
-		if d.serverVersion < MIN_SERVER_VER_ORDER_CONTAINER {
-			version = Version(msgDec.decodeInt64())
-		}
+func (c *Client) processOpenOrderProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.OpenOrder{}
+	msgDec.Unmarshal(&pb)
+	oo := models.NewOpenOrderFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-		orderDecoder := &OrderDecoder{order, contract, orderState, version, d.serverVersion}
-
-		// read orderID
-		orderDecoder.decodeOrderId(msgDec)
-
-		// read contract fields
-		orderDecoder.decodeContractFields(msgDec)
-
-		// read order fields
-		orderDecoder.decodeAction(msgDec)
-		orderDecoder.decodeTotalQuantity(msgDec)
-		orderDecoder.decodeOrderType(msgDec)
-		orderDecoder.decodeLmtPrice(msgDec)
-		orderDecoder.decodeAuxPrice(msgDec)
-		orderDecoder.decodeTIF(msgDec)
-		orderDecoder.decodeOcaGroup(msgDec)
-		orderDecoder.decodeAccount(msgDec)
-		orderDecoder.decodeOpenClose(msgDec)
-		orderDecoder.decodeOrigin(msgDec)
-		orderDecoder.decodeOrderRef(msgDec)
-		orderDecoder.decodeClientId(msgDec)
-		orderDecoder.decodePermId(msgDec)
-		orderDecoder.decodeOutsideRth(msgDec)
-		orderDecoder.decodeHidden(msgDec)
-		orderDecoder.decodeDiscretionaryAmount(msgDec)
-		orderDecoder.decodeGoodAfterTime(msgDec)
-		orderDecoder.skipSharesAllocation(msgDec)
-		orderDecoder.decodeFAParams(msgDec)
-		orderDecoder.decodeModelCode(msgDec)
-		orderDecoder.decodeGoodTillDate(msgDec)
-		orderDecoder.decodeRule80A(msgDec)
-		orderDecoder.decodePercentOffset(msgDec)
-		orderDecoder.decodeSettlingFirm(msgDec)
-		orderDecoder.decodeShortSaleParams(msgDec)
-		orderDecoder.decodeAuctionStrategy(msgDec)
-		orderDecoder.decodeBoxOrderParams(msgDec)
-		orderDecoder.decodePegToStkOrVolOrderParams(msgDec)
-		orderDecoder.decodeDisplaySize(msgDec)
-		orderDecoder.decodeBlockOrder(msgDec)
-		orderDecoder.decodeSweepToFill(msgDec)
-		orderDecoder.decodeAllOrNone(msgDec)
-		orderDecoder.decodeMinQty(msgDec)
-		orderDecoder.decodeOcaType(msgDec)
-		orderDecoder.skipETradeOnly(msgDec)
-		orderDecoder.skipFirmQuoteOnly(msgDec)
-		orderDecoder.skipNbboPriceCap(msgDec)
-		orderDecoder.decodeParentId(msgDec)
-		orderDecoder.decodeTriggerMethod(msgDec)
-		orderDecoder.decodeVolOrderParams(msgDec, true)
-		orderDecoder.decodeTrailParams(msgDec)
-		orderDecoder.decodeBasisPoints(msgDec)
-		orderDecoder.decodeComboLegs(msgDec)
-		orderDecoder.decodeSmartComboRoutingParams(msgDec)
-		orderDecoder.decodeScaleOrderParams(msgDec)
-		orderDecoder.decodeHedgeParams(msgDec)
-		orderDecoder.decodeOptOutSmartRouting(msgDec)
-		orderDecoder.decodeClearingParams(msgDec)
-		orderDecoder.decodeNotHeld(msgDec)
-		orderDecoder.decodeDeltaNeutral(msgDec)
-		orderDecoder.decodeAlgoParams(msgDec)
-		orderDecoder.decodeSolicited(msgDec)
-		orderDecoder.decodeWhatIfInfoAndCommissionAndFees(msgDec)
-		orderDecoder.decodeVolRandomizeFlags(msgDec)
-		orderDecoder.decodePegBenchParams(msgDec)
-		orderDecoder.decodeConditions(msgDec)
-		orderDecoder.decodeAdjustedOrderParams(msgDec)
-		orderDecoder.decodeSoftDollarTier(msgDec)
-		orderDecoder.decodeCashQty(msgDec)
-		orderDecoder.decodeDontUseAutoPriceForHedge(msgDec)
-		orderDecoder.decodeIsOmsContainer(msgDec)
-		orderDecoder.decodeDiscretionaryUpToLimitPrice(msgDec)
-		orderDecoder.decodeUsePriceMgmtAlgo(msgDec)
-		orderDecoder.decodeDuration(msgDec)
-		orderDecoder.decodePostToAts(msgDec)
-		orderDecoder.decodeAutoCancelParent(msgDec, MIN_SERVER_VER_AUTO_CANCEL_PARENT)
-		orderDecoder.decodePegBestPegMidOrderAttributes(msgDec)
-		orderDecoder.decodeCustomerAccount(msgDec)
-		orderDecoder.decodeProfessionalCustomer(msgDec)
-		orderDecoder.decodeBondAccruedInterest(msgDec)
-		orderDecoder.decodeIncludeOvernight(msgDec)
-		orderDecoder.decodeCMETaggingFields(msgDec)
-		orderDecoder.decodeSubmitter(msgDec)
-		orderDecoder.decodeImbalanceOnly(msgDec, MIN_SERVER_VER_IMBALANCE_ONLY)
-
-		d.wrapper.OpenOrder(order.OrderID, contract, order, orderState)
-	}
-
-func (c *Client) processAcctValueMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processOpenOrderCommon(oo)
+}
 
-		msgDec.decode() // version
+func (c *Client) processOpenOrderCommon(oo *models.OpenOrder) error {
+	// Add it to the open orders snapshot being retrieved, if any
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_OPEN_ORDERS, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.OpenOrdersResponse)
 
-		tag := msgDec.decodeString()
-		val := msgDec.decodeString()
-		currency := msgDec.decodeString()
-		accountName := msgDec.decodeString()
+		// Updates of orders placed by other clients can be pushed while the snapshot is being retrieved
+		if !resp.AllClients && !c.isOwnOrder(oo.Order.ClientID) {
+			return false, nil
+		}
 
-		d.wrapper.UpdateAccountValue(tag, val, currency, accountName)
-	}
+		// An order can be reported more than once, i.e.: when it is modified meanwhile, so keep the latest
+		for idx, existing := range resp.OpenOrders {
+			if existing.Order.HasSameID(oo.Order) {
+				resp.OpenOrders[idx] = oo
+				return false, nil
+			}
+		}
+		resp.OpenOrders = append(resp.OpenOrders, oo)
 
-func (c *Client) processPortfolioValueMsg(msgDec *utils.Decoder) error {
+		// Done
+		return false, nil
+	})
 
-	version := msgDec.decodeInt64()
+	// Ignore orders not placed by this client
+	if !c.isOwnOrder(oo.Order.ClientID) {
+		return nil
+	}
 
-	// read contract fields
-	contract := NewContract()
-	contract.ConID = msgDec.decodeInt64() // ver 6 field
-	contract.Symbol = msgDec.decodeString()
-	contract.SecType = msgDec.decodeString()
-	contract.LastTradeDateOrContractMonth = msgDec.decodeString()
-	contract.Strike = msgDec.decodeFloat64()
-	contract.Right = msgDec.decodeString()
+	found := false
+	if oo.Order.OrderID > 0 {
+		found = c.reqMgr.withOrder(models.OrderID(oo.Order.OrderID), func(_resp interface{}) (bool, error) {
+			// Notify
+			if ch, ok := orderEventsChannel(_resp); ok {
+				ch <- oo
+			}
 
-	if version >= 7 {
-		contract.Multiplier = msgDec.decodeString()
-		contract.PrimaryExchange = msgDec.decodeString()
+			// Done (what-if orders are never submitted so no status update will follow)
+			return oo.Order.WhatIf, nil
+		})
 	}
-
-	contract.Currency = msgDec.decodeString()
-	contract.LocalSymbol = msgDec.decodeString() // ver 2 field
-	if version >= 8 {
-		contract.TradingClass = msgDec.decodeString()
+	if !found {
+		c.processAutoOpenOrderEvent(oo)
 	}
-	position := msgDec.decodeDecimal()
 
-	marketPrice := msgDec.decodeFloat64()
-	marketValue := msgDec.decodeFloat64()
-	averageCost := msgDec.decodeFloat64()   // ver 3 field
-	unrealizedPNL := msgDec.decodeFloat64() // ver 3 field
-	realizedPNL := msgDec.decodeFloat64()   // ver 3 field
-
-	accountName := msgDec.decodeString() // ver 4 field
+	// Done
+	return nil
+}
 
-	if version == 6 && d.serverVersion == 39 {
-		contract.PrimaryExchange = msgDec.decodeString()
+func (c *Client) processOpenOrdersEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	d.wrapper.UpdatePortfolio(contract, position, marketPrice, marketValue, averageCost, unrealizedPNL, realizedPNL, accountName)
-
+	// Done
+	return c.processOpenOrdersEndCommon()
 }
 
-func (c *Client) processAcctUpdateTimeMsg(msgDec *utils.Decoder) error {
+func (c *Client) processOpenOrdersEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.OpenOrdersEnd{}
+	msgDec.Unmarshal(&pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-		msgDec.decode() // version
+	// Done
+	return c.processOpenOrdersEndCommon()
+}
 
-		timeStamp := msgDec.decodeString()
+func (c *Client) processOpenOrdersEndCommon() error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_OPEN_ORDERS, func(_ interface{}) (bool, error) {
+		// Done
+		return true, nil
+	})
 
-		d.wrapper.UpdateAccountTime(timeStamp)
-	}
-*/
-func (c *Client) processContractDataMsg(msgDec *message.Decoder) error {
+	// Done
+	return nil
+}
+
+func (c *Client) processTickByTickDataMsg(msgDec *message.Decoder) error {
 	// Gets the originating request ID
 	reqID := msgDec.RequestID(false)
-	cd := models.NewContractDetails()
-	cd.Contract.Symbol = msgDec.String()
-	cd.Contract.SecType = models.NewSecurityTypeFromString(msgDec.String())
-	cd.Contract.LastTradeDateOrContractMonth = msgDec.String()
-	cd.Contract.LastTradeDate = msgDec.String()
-	cd.Contract.Strike = msgDec.FloatMax()
-	cd.Contract.Right = msgDec.String()
-	cd.Contract.Exchange = msgDec.String()
-	cd.Contract.Currency = msgDec.String()
-	cd.Contract.LocalSymbol = msgDec.String()
-	cd.MarketName = msgDec.String()
-	cd.Contract.TradingClass = msgDec.String()
-	cd.Contract.ConID = msgDec.Int32()
-	cd.MinTick = msgDec.FloatMax()
-	cd.Contract.Multiplier = msgDec.FloatMax()
-	cd.OrderTypes = msgDec.String()
-	cd.ValidExchanges = msgDec.String()
-	cd.PriceMagnifier = msgDec.Int32Max()
-	cd.UnderConID = msgDec.Int32Max()
-	cd.LongName = msgDec.EscapedString()
-	cd.Contract.PrimaryExchange = msgDec.String()
-	cd.ContractMonth = msgDec.String()
-	cd.Industry = msgDec.String()
-	cd.Category = msgDec.String()
-	cd.Subcategory = msgDec.String()
-	cd.TimeZoneID = msgDec.String()
-	cd.TradingHours = msgDec.String()
-	cd.LiquidHours = msgDec.String()
-	cd.EVRule = msgDec.String()
-	cd.EVMultiplier = msgDec.Float()
-	secIDListCount := int(msgDec.Int32())
-	if secIDListCount < 0 {
-		msgDec.SetErr(fmt.Errorf("negative security id count: %d", secIDListCount))
-		return msgDec.Err()
-	}
-	cd.SecIDList = make(models.TagValueList, 0, secIDListCount)
-	for i := 0; i < secIDListCount; i++ {
-		tagValue := models.NewTagValue()
-		tagValue.Tag = msgDec.String()
-		tagValue.Value = msgDec.String()
-		cd.SecIDList = append(cd.SecIDList, tagValue)
-	}
-	cd.AggGroup = msgDec.Int32()
-	cd.UnderSymbol = msgDec.String()
-	cd.UnderSecType = models.NewSecurityTypeFromString(msgDec.String())
-	cd.MarketRuleIDs = msgDec.String()
-	cd.RealExpirationDate = msgDec.String()
-	cd.StockType = msgDec.String()
-	cd.MinSize = models.NewDecimalMaxFromMessageDecoder(msgDec)
-	cd.SizeIncrement = models.NewDecimalMaxFromMessageDecoder(msgDec)
-	cd.SuggestedSizeIncrement = models.NewDecimalMaxFromMessageDecoder(msgDec)
-	if cd.Contract.SecType == models.SecurityTypeMutualFund {
-		cd.FundName = msgDec.String()
-		cd.FundFamily = msgDec.String()
-		cd.FundType = msgDec.String()
-		cd.FundFrontLoad = msgDec.String()
-		cd.FundBackLoad = msgDec.String()
-		cd.FundBackLoadTimeInterval = msgDec.String()
-		cd.FundManagementFee = msgDec.String()
-		cd.FundClosed = msgDec.Bool()
-		cd.FundClosedForNewInvestors = msgDec.Bool()
-		cd.FundClosedForNewMoney = msgDec.Bool()
-		cd.FundNotifyAmount = msgDec.String()
-		cd.FundMinimumInitialPurchase = msgDec.String()
-		cd.FundSubsequentMinimumPurchase = msgDec.String()
-		cd.FundBlueSkyStates = msgDec.String()
-		cd.FundBlueSkyTerritories = msgDec.String()
-		cd.FundDistributionPolicyIndicator = models.NewFundDistributionPolicyIndicatorFromString(msgDec.String())
-		cd.FundAssetType = models.NewFundAssetFromString(msgDec.String())
-	}
-	ineligibilityReasonListCount := int(msgDec.Int32())
-	if ineligibilityReasonListCount < 0 {
-		msgDec.SetErr(fmt.Errorf("negative ineligibility reason count: %d", ineligibilityReasonListCount))
-		return msgDec.Err()
-	}
-	cd.IneligibilityReasonList = make([]models.IneligibilityReason, 0, ineligibilityReasonListCount)
-	for i := 0; i < ineligibilityReasonListCount; i++ {
-		ineligibilityReason := models.IneligibilityReason{}
-		ineligibilityReason.ID = msgDec.String()
-		ineligibilityReason.Description = msgDec.String()
-		cd.IneligibilityReasonList = append(cd.IneligibilityReasonList, ineligibilityReason)
-	}
+	tick := models.NewTickByTickFromMessageDecoder(msgDec)
 	if msgDec.Err() != nil {
 		return msgDec.Err()
 	}
 
-	cd.OnContractLastTradeDateOrContractMonthUpdated(false)
-
 	// Done
-	return c.processContractDataCommon(reqID, cd)
+	return c.processTickByTickDataCommon(reqID, tick)
 }
 
-func (c *Client) processContractDataProtobuf(msgDec *protofmt.Decoder) error {
-	pb := protobuf.ContractData{}
+func (c *Client) processTickByTickDataProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.TickByTickData{}
 	msgDec.Unmarshal(&pb)
-	if msgDec.Err() != nil {
-		return msgDec.Err()
-	}
-	if pb.ContractDetails == nil || pb.Contract == nil {
-		return nil
-	}
 	// Gets the originating request ID
 	reqID := msgDec.RequestID(pb.ReqId, false)
-	cd := models.NewContractDetailsFromProtobufDecoder(msgDec, pb.ContractDetails, pb.Contract)
+	tick := models.NewTickByTickFromProtobufDecoder(msgDec, &pb)
 	if msgDec.Err() != nil {
 		return msgDec.Err()
 	}
 
-	cd.OnContractLastTradeDateOrContractMonthUpdated(false)
-
 	// Done
-	return c.processContractDataCommon(reqID, cd)
+	return c.processTickByTickDataCommon(reqID, tick)
 }
 
-/*
-		private void processContractDataMsgProtoBuf() throws IOException {
-		    byte[] byteArray = readByteArray();
-
-		    ContractDataProto.ContractData contractDataProto = ContractDataProto.ContractData.parseFrom(byteArray);
-		    m_EWrapper.contractDataProtoBuf(contractDataProto);
+func (c *Client) processTickByTickDataCommon(reqID int32, tick models.TickByTick) error {
+	// Ignore ticks without data
+	if tick == nil {
+		return nil
+	}
 
-		    int reqId = contractDataProto.hasReqId() ? contractDataProto.getReqId() : EClientErrors.NO_VALID_ID;
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.TickByTickResponse)
 
-		    if (!contractDataProto.hasContract() || !contractDataProto.hasContractDetails()) {
-		        return;
-		    }
-		    // set contract details fields
-		    ContractDetails contractDetails = EDecoderUtils.decodeContractDetails(contractDataProto.getContract(), contractDataProto.getContractDetails(), false);
+		// Notify
+		resp.Channel <- tick
 
-		    m_EWrapper.contractDetails(reqId, contractDetails);
-		}
+		// Done
+		return false, nil
+	})
 
-	    private void processBondContractDataMsgProtoBuf() throws IOException {
-	        byte[] byteArray = readByteArray();
+	// Done
+	return nil
+}
 
-	        ContractDataProto.ContractData contractDataProto = ContractDataProto.ContractData.parseFrom(byteArray);
-	        m_EWrapper.bondContractDataProtoBuf(contractDataProto);
+func (c *Client) processCompletedOrderMsg(msgDec *message.Decoder) error {
+	co := models.NewCompletedOrderFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	        int reqId = contractDataProto.hasReqId() ? contractDataProto.getReqId() : EClientErrors.NO_VALID_ID;
+	// Done
+	return c.processCompletedOrderCommon(co)
+}
 
-	        if (!contractDataProto.hasContract() || !contractDataProto.hasContractDetails()) {
+func (c *Client) processCompletedOrderProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.CompletedOrder{}
+	msgDec.Unmarshal(&pb)
+	co := models.NewCompletedOrderFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processCompletedOrderCommon(co)
+}
+
+func (c *Client) processCompletedOrderCommon(co *models.CompletedOrder) error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_COMPLETED_ORDERS, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.CompletedOrdersResponse)
+
+		resp.CompletedOrders = append(resp.CompletedOrders, co)
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processCompletedOrdersEndMsg(_ *message.Decoder) error {
+	// Done
+	return c.processCompletedOrdersEndCommon()
+}
+
+func (c *Client) processCompletedOrdersEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.CompletedOrdersEnd{}
+	msgDec.Unmarshal(&pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processCompletedOrdersEndCommon()
+}
+
+func (c *Client) processCompletedOrdersEndCommon() error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_COMPLETED_ORDERS, func(_ interface{}) (bool, error) {
+		// Done
+		return true, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processExecutionDetailsMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(true)
+	ed := models.NewExecutionDetailsFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processExecutionDetailsCommon(reqID, ed)
+}
+
+func (c *Client) processExecutionDetailsProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.ExecutionDetails{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, true)
+	ed := models.NewExecutionDetailsFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processExecutionDetailsCommon(reqID, ed)
+}
+
+func (c *Client) processExecutionDetailsCommon(reqID int32, ed *models.ExecutionDetails) error {
+	if reqID > 0 {
+		c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+			resp := _resp.(*models.ExecutionsResponse)
+
+			resp.Executions = append(resp.Executions, ed)
+
+			// Wait for the commission report
+			c.execTracker.trackRequestExecution(reqID, ed)
+
+			// Done
+			return false, nil
+		})
+	} else {
+		// A live fill, keep it until the commission report arrives if someone is listening
+		_ = c.trackLiveExecution(ed)
+	}
+
+	// Done
+	return nil
+}
+
+func (c *Client) processExecutionDetailsEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processExecutionDetailsEndCommon(reqID)
+}
+
+func (c *Client) processExecutionDetailsEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.ExecutionDetailsEnd{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processExecutionDetailsEndCommon(reqID)
+}
+
+func (c *Client) processExecutionDetailsEndCommon(reqID int32) error {
+	// Keep the request open until all the commission reports arrive or the timeout elapses
+	if c.execTracker.endRequest(reqID) {
+		time.AfterFunc(defaultCommissionReportTimeout, func() {
+			c.completeExecutionsRequest(reqID)
+		})
+	} else {
+		c.completeExecutionsRequest(reqID)
+	}
+
+	// Done
+	return nil
+}
+
+func (c *Client) processCommissionAndFeesReportMsg(msgDec *message.Decoder) error {
+	report := models.NewCommissionAndFeesReportFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Attach it to the pending executions, emit the live fills and complete the requests that got all their reports
+	fills, completedReqIDs := c.execTracker.attachReport(report)
+	for _, ed := range fills {
+		c.emitFill(ed)
+	}
+	for _, reqID := range completedReqIDs {
+		c.completeExecutionsRequest(reqID)
+	}
+
+	// Done
+	return nil
+}
+
+// processAutoOpenOrderEvent forwards events of orders not tracked by PlaceOrder, like the ones entered in TWS, to the
+// auto open orders binding, if active.
+func (c *Client) processAutoOpenOrderEvent(evt models.OrderEvent) {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_AUTO_OPEN_ORDERS, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.AutoOpenOrdersResponse)
+
+		// Notify
+		resp.Channel <- evt
+
+		// Done
+		return false, nil
+	})
+}
+
+func (c *Client) processAcctValueMsg(msgDec *message.Decoder) error {
+	av := models.NewAccountValueFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(av)
+}
+
+func (c *Client) processAcctValueProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountValue{}
+	msgDec.Unmarshal(&pb)
+	av := models.NewAccountValueFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(av)
+}
+
+func (c *Client) processPortfolioValueMsg(msgDec *message.Decoder) error {
+	pi := models.NewPortfolioItemFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(pi)
+}
+
+func (c *Client) processPortfolioValueProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.PortfolioValue{}
+	msgDec.Unmarshal(&pb)
+	pi := models.NewPortfolioItemFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(pi)
+}
+
+func (c *Client) processAcctUpdateTimeMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	aut := &models.AccountUpdateTime{
+		Time: msgDec.String(),
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(aut)
+}
+
+func (c *Client) processAcctUpdateTimeProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountUpdateTime{}
+	msgDec.Unmarshal(&pb)
+	aut := &models.AccountUpdateTime{
+		Time: msgDec.String(pb.TimeStamp),
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(aut)
+}
+
+func (c *Client) processAcctDownloadEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	ade := &models.AccountDownloadEnd{
+		Account: msgDec.String(),
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(ade)
+}
+
+func (c *Client) processAcctDownloadEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountDataEnd{}
+	msgDec.Unmarshal(&pb)
+	ade := &models.AccountDownloadEnd{
+		Account: msgDec.String(pb.AccountName),
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateCommon(ade)
+}
+
+func (c *Client) processAccountUpdateCommon(upd models.AccountUpdate) error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_ACCT_DATA, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.AccountUpdatesResponse)
+
+		// Update the snapshot before notifying so it is consistent with the received update
+		resp.Snapshot.Update(upd)
+
+		// Notify
+		resp.Channel <- upd
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processAccountSummaryMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	upd := models.NewAccountSummaryUpdateFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountSummaryCommon(reqID, upd)
+}
+
+func (c *Client) processAccountSummaryProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountSummary{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	upd := models.NewAccountSummaryUpdateFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountSummaryCommon(reqID, upd)
+}
+
+func (c *Client) processAccountSummaryCommon(reqID int32, upd models.AccountSummaryUpdate) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		switch resp := _resp.(type) {
+		case *models.AccountSummarySnapshotResponse:
+			resp.Summary.Update(upd)
+
+		case *models.AccountSummaryResponse:
+			// Notify
+			resp.Channel <- upd
+		}
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processAccountSummaryEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountSummaryEndCommon(reqID)
+}
+
+func (c *Client) processAccountSummaryEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountSummaryEnd{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountSummaryEndCommon(reqID)
+}
+
+func (c *Client) processAccountSummaryEndCommon(reqID int32) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		// Only the snapshot request completes, the subscription keeps receiving updates
+		_, isSnapshot := _resp.(*models.AccountSummarySnapshotResponse)
+
+		// Done
+		return isSnapshot, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processAccountUpdateMultiMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	av := models.NewAccountMultiValueFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateMultiCommon(reqID, av)
+}
+
+func (c *Client) processAccountUpdateMultiProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountUpdateMulti{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	av := models.NewAccountMultiValueFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateMultiCommon(reqID, av)
+}
+
+func (c *Client) processAccountUpdateMultiEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateMultiCommon(reqID, &models.AccountMultiEnd{})
+}
+
+func (c *Client) processAccountUpdateMultiEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.AccountUpdateMultiEnd{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processAccountUpdateMultiCommon(reqID, &models.AccountMultiEnd{})
+}
+
+func (c *Client) processAccountUpdateMultiCommon(reqID int32, upd models.AccountUpdateMulti) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.AccountUpdatesMultiResponse)
+
+		// Notify
+		resp.Channel <- upd
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processPositionDataMsg(msgDec *message.Decoder) error {
+	pos := models.NewPositionFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionDataCommon(pos)
+}
+
+func (c *Client) processPositionDataProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.Position{}
+	msgDec.Unmarshal(&pb)
+	pos := models.NewPositionFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionDataCommon(pos)
+}
+
+func (c *Client) processPositionDataCommon(pos *models.Position) error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_POSITIONS, func(_resp interface{}) (bool, error) {
+		addPositionUpdate(_resp, pos)
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processPositionEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionEndCommon()
+}
+
+func (c *Client) processPositionEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.PositionEnd{}
+	msgDec.Unmarshal(&pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionEndCommon()
+}
+
+func (c *Client) processPositionEndCommon() error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_POSITIONS, func(_resp interface{}) (bool, error) {
+		// Done
+		return addPositionUpdate(_resp, &models.PositionEnd{}), nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processPositionMultiMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	pos := models.NewPositionMultiFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionMultiCommon(reqID, pos)
+}
+
+func (c *Client) processPositionMultiProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.PositionMulti{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	pos := models.NewPositionMultiFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionMultiCommon(reqID, pos)
+}
+
+func (c *Client) processPositionMultiCommon(reqID int32, pos *models.Position) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		addPositionUpdate(_resp, pos)
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processPositionMultiEndMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionMultiEndCommon(reqID)
+}
+
+func (c *Client) processPositionMultiEndProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.PositionMultiEnd{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPositionMultiEndCommon(reqID)
+}
+
+func (c *Client) processPositionMultiEndCommon(reqID int32) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		// Done
+		return addPositionUpdate(_resp, &models.PositionEnd{}), nil
+	})
+
+	// Done
+	return nil
+}
+
+// addPositionUpdate stores or notifies a position update depending on the kind of request. It returns true if the
+// request is complete, which only happens for snapshots once the end is received.
+func addPositionUpdate(_resp interface{}, upd models.PositionUpdate) bool {
+	switch resp := _resp.(type) {
+	case *models.PositionsSnapshotResponse:
+		if pos, ok := upd.(*models.Position); ok {
+			resp.Positions = append(resp.Positions, *pos)
+			return false
+		}
+		return true
+
+	case *models.PositionsResponse:
+		// Notify
+		resp.Channel <- upd
+	}
+	return false
+}
+
+func (c *Client) processPnLMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	pnl := models.NewPnLFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPnLCommon(reqID, pnl)
+}
+
+func (c *Client) processPnLSingleMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	pnl := models.NewPnLSingleFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processPnLCommon(reqID, pnl)
+}
+
+func (c *Client) processPnLCommon(reqID int32, pnl *models.PnL) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.PnLResponse)
+
+		// Notify
+		resp.Channel <- pnl
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processNextValidIdMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	orderID := models.OrderID(msgDec.Int32())
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Update the order ID allocator
+	c.orderIDs.seed(orderID)
+
+	c.reqMgr.withRequestWithoutID(common.REQ_IDS, func(_ interface{}) error {
+		// Done
+		return nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processContractDataMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	cd := models.NewContractDetails()
+	cd.Contract.Symbol = msgDec.String()
+	cd.Contract.SecType = models.NewSecurityTypeFromString(msgDec.String())
+	cd.Contract.LastTradeDateOrContractMonth = msgDec.String()
+	cd.Contract.LastTradeDate = msgDec.String()
+	cd.Contract.Strike = msgDec.FloatMax()
+	cd.Contract.Right = msgDec.String()
+	cd.Contract.Exchange = msgDec.String()
+	cd.Contract.Currency = msgDec.String()
+	cd.Contract.LocalSymbol = msgDec.String()
+	cd.MarketName = msgDec.String()
+	cd.Contract.TradingClass = msgDec.String()
+	cd.Contract.ConID = msgDec.Int32()
+	cd.MinTick = msgDec.FloatMax()
+	cd.Contract.Multiplier = msgDec.FloatMax()
+	cd.OrderTypes = msgDec.String()
+	cd.ValidExchanges = msgDec.String()
+	cd.PriceMagnifier = msgDec.Int32Max()
+	cd.UnderConID = msgDec.Int32Max()
+	cd.LongName = msgDec.EscapedString()
+	cd.Contract.PrimaryExchange = msgDec.String()
+	cd.ContractMonth = msgDec.String()
+	cd.Industry = msgDec.String()
+	cd.Category = msgDec.String()
+	cd.Subcategory = msgDec.String()
+	cd.TimeZoneID = msgDec.String()
+	cd.TradingHours = msgDec.String()
+	cd.LiquidHours = msgDec.String()
+	cd.EVRule = msgDec.String()
+	cd.EVMultiplier = msgDec.Float()
+	secIDListCount := int(msgDec.Int32())
+	if secIDListCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative security id count: %d", secIDListCount))
+		return msgDec.Err()
+	}
+	cd.SecIDList = make(models.TagValueList, 0, secIDListCount)
+	for i := 0; i < secIDListCount; i++ {
+		tagValue := models.NewTagValue()
+		tagValue.Tag = msgDec.String()
+		tagValue.Value = msgDec.String()
+		cd.SecIDList = append(cd.SecIDList, tagValue)
+	}
+	cd.AggGroup = msgDec.Int32()
+	cd.UnderSymbol = msgDec.String()
+	cd.UnderSecType = models.NewSecurityTypeFromString(msgDec.String())
+	cd.MarketRuleIDs = msgDec.String()
+	cd.RealExpirationDate = msgDec.String()
+	cd.StockType = msgDec.String()
+	cd.MinSize = models.NewDecimalMaxFromMessageDecoder(msgDec)
+	cd.SizeIncrement = models.NewDecimalMaxFromMessageDecoder(msgDec)
+	cd.SuggestedSizeIncrement = models.NewDecimalMaxFromMessageDecoder(msgDec)
+	if cd.Contract.SecType == models.SecurityTypeMutualFund {
+		cd.FundName = msgDec.String()
+		cd.FundFamily = msgDec.String()
+		cd.FundType = msgDec.String()
+		cd.FundFrontLoad = msgDec.String()
+		cd.FundBackLoad = msgDec.String()
+		cd.FundBackLoadTimeInterval = msgDec.String()
+		cd.FundManagementFee = msgDec.String()
+		cd.FundClosed = msgDec.Bool()
+		cd.FundClosedForNewInvestors = msgDec.Bool()
+		cd.FundClosedForNewMoney = msgDec.Bool()
+		cd.FundNotifyAmount = msgDec.String()
+		cd.FundMinimumInitialPurchase = msgDec.String()
+		cd.FundSubsequentMinimumPurchase = msgDec.String()
+		cd.FundBlueSkyStates = msgDec.String()
+		cd.FundBlueSkyTerritories = msgDec.String()
+		cd.FundDistributionPolicyIndicator = models.NewFundDistributionPolicyIndicatorFromString(msgDec.String())
+		cd.FundAssetType = models.NewFundAssetFromString(msgDec.String())
+	}
+	ineligibilityReasonListCount := int(msgDec.Int32())
+	if ineligibilityReasonListCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative ineligibility reason count: %d", ineligibilityReasonListCount))
+		return msgDec.Err()
+	}
+	cd.IneligibilityReasonList = make([]models.IneligibilityReason, 0, ineligibilityReasonListCount)
+	for i := 0; i < ineligibilityReasonListCount; i++ {
+		ineligibilityReason := models.IneligibilityReason{}
+		ineligibilityReason.ID = msgDec.String()
+		ineligibilityReason.Description = msgDec.String()
+		cd.IneligibilityReasonList = append(cd.IneligibilityReasonList, ineligibilityReason)
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	cd.OnContractLastTradeDateOrContractMonthUpdated(false)
+
+	// Done
+	return c.processContractDataCommon(reqID, cd)
+}
+
+func (c *Client) processContractDataProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.ContractData{}
+	msgDec.Unmarshal(&pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+	if pb.ContractDetails == nil || pb.Contract == nil {
+		return nil
+	}
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	cd := models.NewContractDetailsFromProtobufDecoder(msgDec, pb.ContractDetails, pb.Contract)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	cd.OnContractLastTradeDateOrContractMonthUpdated(false)
+
+	// Done
+	return c.processContractDataCommon(reqID, cd)
+}
+
+/*
+		private void processContractDataMsgProtoBuf() throws IOException {
+		    byte[] byteArray = readByteArray();
+
+		    ContractDataProto.ContractData contractDataProto = ContractDataProto.ContractData.parseFrom(byteArray);
+		    m_EWrapper.contractDataProtoBuf(contractDataProto);
+
+		    int reqId = contractDataProto.hasReqId() ? contractDataProto.getReqId() : EClientErrors.NO_VALID_ID;
+
+		    if (!contractDataProto.hasContract() || !contractDataProto.hasContractDetails()) {
+		        return;
+		    }
+		    // set contract details fields
+		    ContractDetails contractDetails = EDecoderUtils.decodeContractDetails(contractDataProto.getContract(), contractDataProto.getContractDetails(), false);
+
+		    m_EWrapper.contractDetails(reqId, contractDetails);
+		}
+
+	    private void processBondContractDataMsgProtoBuf() throws IOException {
+	        byte[] byteArray = readByteArray();
+
+	        ContractDataProto.ContractData contractDataProto = ContractDataProto.ContractData.parseFrom(byteArray);
+	        m_EWrapper.bondContractDataProtoBuf(contractDataProto);
+
+	        int reqId = contractDataProto.hasReqId() ? contractDataProto.getReqId() : EClientErrors.NO_VALID_ID;
+
+	        if (!contractDataProto.hasContract() || !contractDataProto.hasContractDetails()) {
 	            return;
 	        }
 	        // set contract details fields
@@ -1259,113 +1997,6 @@ func (c *Client) processContractDataCommon(reqID int32, cd *models.ContractDetai
 	return nil
 }
 
-/*
-func (c *Client) processExecutionDetailsMsg(msgDec *utils.Decoder) error {
-
-	version := d.serverVersion
-	if d.serverVersion < MIN_SERVER_VER_LAST_LIQUIDITY {
-		version = Version(msgDec.decodeInt64())
-	}
-
-	var reqID int64 = -1
-	if version >= 7 {
-		reqID = msgDec.decodeInt64()
-	}
-
-	orderID := msgDec.decodeInt64()
-
-	// decode contact fields
-	contract := NewContract()
-	contract.ConID = msgDec.decodeInt64()
-	contract.Symbol = msgDec.decodeString()
-	contract.SecType = msgDec.decodeString()
-	contract.LastTradeDateOrContractMonth = msgDec.decodeString()
-	contract.Strike = msgDec.decodeFloat64()
-	contract.Right = msgDec.decodeString()
-
-	if version >= 9 {
-		contract.Multiplier = msgDec.decodeString()
-	}
-
-	contract.Exchange = msgDec.decodeString()
-	contract.Currency = msgDec.decodeString()
-	contract.LocalSymbol = msgDec.decodeString()
-
-	if version >= 10 {
-		contract.TradingClass = msgDec.decodeString()
-	}
-
-	// read execution fields
-	execution := NewExecution()
-	execution.OrderID = orderID
-	execution.ExecID = msgDec.decodeString()
-	execution.Time = msgDec.decodeString()
-	execution.AcctNumber = msgDec.decodeString()
-	execution.Exchange = msgDec.decodeString()
-	execution.Side = msgDec.decodeString()
-	execution.Shares = msgDec.decodeDecimal()
-	execution.Price = msgDec.decodeFloat64()
-	execution.PermID = msgDec.decodeInt64()
-	execution.ClientID = msgDec.decodeInt64()
-	execution.Liquidation = msgDec.decodeInt64()
-
-	if version >= 6 {
-		execution.CumQty = msgDec.decodeDecimal()
-		execution.AvgPrice = msgDec.decodeFloat64()
-	}
-
-	if version >= 8 {
-		execution.OrderRef = msgDec.decodeString()
-	}
-
-	if version >= 9 {
-		execution.EVRule = msgDec.decodeString()
-		execution.EVMultiplier = msgDec.decodeFloat64()
-	}
-
-	if d.serverVersion >= MIN_SERVER_VER_MODELS_SUPPORT {
-		execution.ModelCode = msgDec.decodeString()
-	}
-
-	if d.serverVersion >= MIN_SERVER_VER_LAST_LIQUIDITY {
-		execution.LastLiquidity = msgDec.decodeInt64()
-	}
-
-	if d.serverVersion >= MIN_SERVER_VER_PENDING_PRICE_REVISION {
-		execution.PendingPriceRevision = msgDec.decodeBool()
-	}
-
-	if d.serverVersion >= MIN_SERVER_VER_SUBMITTER {
-		execution.Submitter = msgDec.decodeString()
-	}
-
-	d.wrapper.ExecDetails(reqID, contract, execution)
-}
-
-func (c *Client) processExecutionDetailsMsgProtoBuf(msgDec *utils.Decoder) error {
-
-	var executionDetailsProto protobuf.ExecutionDetails
-	err := proto.Unmarshal(msgDec.Bytes(), &executionDetailsProto)
-	if err != nil {
-		log.Panic().Err(err).Msg("processExecutionDetailsMsgProtoBuf unmarshal error")
-	}
-
-	var reqID int64 = int64(executionDetailsProto.GetReqId())
-
-	var contract *Contract
-	if executionDetailsProto.Contract != nil {
-		contract = decodeContract(executionDetailsProto.GetContract())
-	}
-
-	var execution *Execution
-	if executionDetailsProto.Execution != nil {
-		execution = decodeExecution(executionDetailsProto.GetExecution())
-	}
-
-	d.wrapper.ExecDetails(reqID, contract, execution)
-}
-*/
-
 func (c *Client) processMarketDepthMsg(msgDec *message.Decoder) error {
 	msgDec.Skip() // version
 	// Gets the originating ticker ID
@@ -1504,20 +2135,6 @@ func (c *Client) processMarketDepthL2Protobuf(msgDec *protofmt.Decoder) error {
 	)
 }
 
-/*
-func (c *Client) processNewsBulletinsMsg(msgDec *utils.Decoder) error {
-
-	msgDec.decode() // version
-
-	msgID := msgDec.decodeInt64()
-	msgType := msgDec.decodeInt64()
-	newsMessage := msgDec.decodeString()
-	originExch := msgDec.decodeString()
-
-	d.wrapper.UpdateNewsBulletin(msgID, msgType, newsMessage, originExch)
-}
-*/
-
 func (c *Client) processManagedAccountsMsg(msgDec *message.Decoder) error {
 	msgDec.Skip() // version
 	accountsNames := msgDec.String()
@@ -1559,17 +2176,32 @@ func (c *Client) processManagedAccountsCommon(accounts []string) error {
 	return nil
 }
 
-/*
-func (c *Client) processReceiveFaMsg(msgDec *utils.Decoder) error {
+func (c *Client) processReceiveFaMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	faData := models.FaData(msgDec.Int32())
+	doc := msgDec.String()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-		msgDec.decode() // version
+	// Done
+	return c.processReceiveFaCommon(faData, doc)
+}
 
-		faDataType := FaDataType(msgDec.decodeInt64())
-		cxml := msgDec.decodeString()
+func (c *Client) processReceiveFaCommon(faData models.FaData, doc string) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_FA, func(_resp interface{}) error {
+		resp := _resp.(*models.FaResponse)
 
-		d.wrapper.ReceiveFA(faDataType, cxml)
-	}
-*/
+		resp.FaData = faData
+		resp.XML = doc
+
+		// Done
+		return nil
+	})
+
+	// Done
+	return nil
+}
 
 func (c *Client) processHistoricalDataMsg(msgDec *message.Decoder) error {
 	// Gets the originating request ID
@@ -1627,9 +2259,13 @@ func (c *Client) processHistoricalDataProtobuf(msgDec *protofmt.Decoder) error {
 
 func (c *Client) processHistoricalDataCommon(reqID int32, bars []models.HistoricalDataBar) error {
 	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.HistoricalDataResponse)
+		switch resp := _resp.(type) {
+		case *models.HistoricalDataResponse:
+			resp.Bars = append(resp.Bars, bars...)
 
-		resp.Bars = append(resp.Bars, bars...)
+		case *historicalDataStream:
+			resp.addBackfillBars(bars)
+		}
 
 		// Done
 		return false, nil
@@ -1666,7 +2302,13 @@ func (c *Client) processHistoricalDataEndProtobuf(msgDec *protofmt.Decoder) erro
 }
 
 func (c *Client) processHistoricalDataEndCommon(tickerID int32) error {
-	c.reqMgr.withRequestWithID(tickerID, func(_ interface{}) (bool, error) {
+	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
+		// Subscriptions remain active to receive the updates
+		if stream, ok := _resp.(*historicalDataStream); ok {
+			stream.endBackfill()
+			return false, nil
+		}
+
 		// Signal end of snapshot
 		return true, nil
 	})
@@ -1675,82 +2317,146 @@ func (c *Client) processHistoricalDataEndCommon(tickerID int32) error {
 	return nil
 }
 
-/*
-func (c *Client) processScannerDataMsg(msgDec *utils.Decoder) error {
+func (c *Client) processHistoricalDataUpdateMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	bar := models.NewHistoricalDataBarFromUpdateMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processHistoricalDataUpdateCommon(reqID, bar)
+}
 
-		msgDec.Skip() // version
+func (c *Client) processHistoricalDataUpdateProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.HistoricalDataUpdate{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	bar := models.NewHistoricalDataBarFromProtobufDecoder(msgDec, pb.HistoricalDataBar)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-		reqID := msgDec.decodeInt64()
+	// Done
+	return c.processHistoricalDataUpdateCommon(reqID, bar)
+}
 
-		numberOfElements := msgDec.decodeInt64()
+func (c *Client) processHistoricalDataUpdateCommon(reqID int32, bar models.HistoricalDataBar) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		if stream, ok := _resp.(*historicalDataStream); ok {
+			stream.update(bar)
+		}
 
-		var i int64
-		for i = 0; i < numberOfElements; i++ {
-
-			contractDetails := NewContractDetails()
-
-			rank := msgDec.decodeInt64()
-			contractDetails.Contract.ConID = msgDec.decodeInt64()
-			contractDetails.Contract.Symbol = msgDec.decodeString()
-			contractDetails.Contract.SecType = msgDec.decodeString()
-			contractDetails.Contract.LastTradeDateOrContractMonth = msgDec.decodeString()
-			contractDetails.Contract.Strike = msgDec.decodeFloat64()
-			contractDetails.Contract.Right = msgDec.decodeString()
-			contractDetails.Contract.Exchange = msgDec.decodeString()
-			contractDetails.Contract.Currency = msgDec.decodeString()
-			contractDetails.Contract.LocalSymbol = msgDec.decodeString()
-			contractDetails.MarketName = msgDec.decodeString()
-			contractDetails.Contract.TradingClass = msgDec.decodeString()
-			distance := msgDec.decodeString()
-			benchmark := msgDec.decodeString()
-			projection := msgDec.decodeString()
-			legsStr := msgDec.decodeString()
-
-			d.wrapper.ScannerData(reqID, rank, contractDetails, distance, benchmark, projection, legsStr)
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processScannerDataMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	resultsCount := int(msgDec.Int32())
+	if resultsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative scanner results count: %d", resultsCount))
+		return msgDec.Err()
+	}
+	results := make([]*models.ScannerResult, 0, resultsCount)
+	for i := 0; i < resultsCount; i++ {
+		results = append(results, models.NewScannerResultFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processScannerDataCommon(reqID, results)
+}
+
+func (c *Client) processScannerDataCommon(reqID int32, results []*models.ScannerResult) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.ScannerResponse)
 
+		// Notify. Each result set replaces the previous one so, if the consumer did not pick the last one yet, drop it.
+		select {
+		case resp.Channel <- results:
+		default:
+			select {
+			case <-resp.Channel:
+			default:
+			}
+			resp.Channel <- results
 		}
 
-		d.wrapper.ScannerDataEnd(reqID)
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processScannerParametersMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	xmlStr := msgDec.String()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processScannerParametersMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processScannerParametersCommon(xmlStr)
+}
+
+func (c *Client) processScannerParametersCommon(xmlStr string) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_SCANNER_PARAMETERS, func(_resp interface{}) error {
+		resp := _resp.(*models.ScannerParametersResponse)
 
-		msgDec.decode() // version
+		params, err := models.NewScannerParametersFromXML(xmlStr)
+		if err != nil {
+			return err
+		}
+		resp.Parameters = params
+
+		// Done
+		return nil
+	})
 
-		xml := msgDec.decodeString()
+	// Done
+	return nil
+}
 
-		d.wrapper.ScannerParameters(xml)
+func (c *Client) processFundamentalDataMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	data := msgDec.String()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processRealTimeBarsMsg(msgDec *utils.Decoder) error {
-
-	msgDec.decode() // version
+	// Done
+	return c.processFundamentalDataCommon(reqID, data)
+}
 
-	reqID := msgDec.decodeInt64()
+func (c *Client) processFundamentalDataCommon(reqID int32, data string) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.FundamentalDataResponse)
 
-	time := msgDec.decodeInt64()
-	open := msgDec.decodeFloat64()
-	high := msgDec.decodeFloat64()
-	low := msgDec.decodeFloat64()
-	close := msgDec.decodeFloat64()
-	volume := msgDec.decodeDecimal()
-	wap := msgDec.decodeDecimal()
-	count := msgDec.decodeInt64()
+		resp.Data, resp.ParseErr = models.NewFundamentalDataFromXML(resp.ReportType, data)
 
-	d.wrapper.RealtimeBar(reqID, time, open, high, low, close, volume, wap, count)
+		// Done
+		return true, nil
+	})
 
+	// Done
+	return nil
 }
 
-func (c *Client) processFundamentalDataMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		reqID := msgDec.decodeInt64()
-		data := msgDec.decodeString()
-
-		d.wrapper.FundamentalData(reqID, data)
-	}
-*/
 func (c *Client) processContractDataEndMsg(msgDec *message.Decoder) error {
 	msgDec.Skip() // version
 	// Gets the originating request ID
@@ -1787,50 +2493,6 @@ func (c *Client) processContractDataEndCommon(reqID int32) error {
 }
 
 /*
-func (c *Client) processOpenOrdersEndMsg(msgDec *utils.Decoder) error {
-	msgDec.Skip() // version
-	if msgDec.Err() != nil {
-		return msgDec.Err()
-	}
-
-	// Notify
-	c.eventsHandler.OpenOrdersEnd()
-
-	// Done
-	return nil
-}
-
-func (c *Client) processAcctDownloadEndMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		accountName := msgDec.decodeString()
-
-		d.wrapper.AccountDownloadEnd(accountName)
-	}
-
-func (c *Client) processExecutionDetailsEndMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		reqID := msgDec.decodeInt64()
-
-		d.wrapper.ExecDetailsEnd(reqID)
-	}
-
-func (c *Client) processExecutionDetailsEndMsgProtoBuf(msgDec *utils.Decoder) error {
-
-		var executionDetailsEndProto protobuf.ExecutionDetailsEnd
-		err := proto.Unmarshal(msgDec.Bytes(), &executionDetailsEndProto)
-		if err != nil {
-			log.Panic().Err(err).Msg("processExecutionDetailsEndMsgProtoBuf unmarshal error")
-		}
-
-		var reqID int64 = int64(executionDetailsEndProto.GetReqId())
-
-		d.wrapper.ExecDetailsEnd(reqID)
-	}
-
 func (c *Client) processDeltaNeutralValidationMsg(msgDec *utils.Decoder) error {
 
 		msgDec.decode() // version
@@ -1883,80 +2545,6 @@ func (c *Client) processTickSnapshotEndCommon(tickerID int32) error {
 }
 
 /*
-func (c *Client) processCommissionAndFeesReportMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		commissionAndFeesReport := NewCommissionAndFeesReport()
-		commissionAndFeesReport.ExecID = msgDec.decodeString()
-		commissionAndFeesReport.CommissionAndFees = msgDec.decodeFloat64()
-		commissionAndFeesReport.Currency = msgDec.decodeString()
-		commissionAndFeesReport.RealizedPNL = msgDec.decodeFloat64()
-		commissionAndFeesReport.Yield = msgDec.decodeFloat64()
-		commissionAndFeesReport.YieldRedemptionDate = msgDec.decodeInt64()
-
-		d.wrapper.CommissionAndFeesReport(commissionAndFeesReport)
-	}
-
-func (c *Client) processPositionDataMsg(msgDec *utils.Decoder) error {
-
-		version := msgDec.decodeInt64()
-
-		account := msgDec.decodeString()
-
-		// decode contract fields
-		contract := NewContract()
-		contract.ConID = msgDec.decodeInt64()
-		contract.Symbol = msgDec.decodeString()
-		contract.SecType = msgDec.decodeString()
-		contract.LastTradeDateOrContractMonth = msgDec.decodeString()
-		contract.Strike = msgDec.decodeFloat64()
-		contract.Right = msgDec.decodeString()
-		contract.Multiplier = msgDec.decodeString()
-		contract.Exchange = msgDec.decodeString()
-		contract.Currency = msgDec.decodeString()
-		contract.LocalSymbol = msgDec.decodeString()
-		if version >= 2 {
-			contract.TradingClass = msgDec.decodeString()
-		}
-
-		position := msgDec.decodeDecimal()
-
-		var avgCost float64
-		if version >= 3 {
-			avgCost = msgDec.decodeFloat64()
-		}
-
-		d.wrapper.Position(account, contract, position, avgCost)
-	}
-
-func (c *Client) processPositionEndMsg(*msgDecfer) {
-
-		d.wrapper.PositionEnd()
-	}
-
-func (c *Client) processAccountSummaryMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		reqID := msgDec.decodeInt64()
-		account := msgDec.decodeString()
-		tag := msgDec.decodeString()
-		value := msgDec.decodeString()
-		currency := msgDec.decodeString()
-
-		d.wrapper.AccountSummary(reqID, account, tag, value, currency)
-	}
-
-func (c *Client) processAccountSummaryEndMsg(msgDec *utils.Decoder) error {
-
-		msgDec.decode() // version
-
-		reqID := msgDec.decodeInt64()
-
-		d.wrapper.AccountSummaryEnd(reqID)
-	}
-
 func (c *Client) processVerifyMessageApiMsg(msgDec *utils.Decoder) error {
 
 		msgDec.decode() // version
@@ -2015,303 +2603,505 @@ func (c *Client) processVerifyAndAuthCompletedMsg(msgDec *utils.Decoder) error {
 
 		d.wrapper.VerifyAndAuthCompleted(isSuccessful, errorText)
 	}
+*/
+
+func (c *Client) processSymbolSamplesMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	contractDescriptionsCount := int(msgDec.Int32())
+	if contractDescriptionsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative contract descriptions count: %d", contractDescriptionsCount))
+		return msgDec.Err()
+	}
+	cds := make([]*models.ContractDescription, 0, contractDescriptionsCount)
+	for i := 0; i < contractDescriptionsCount; i++ {
+		cd := models.NewContractDescription()
+		cd.Contract.ConID = msgDec.Int32()
+		cd.Contract.Symbol = msgDec.String()
+		cd.Contract.SecType = models.NewSecurityTypeFromString(msgDec.String())
+		cd.Contract.PrimaryExchange = msgDec.String()
+		cd.Contract.Currency = msgDec.String()
+		derivativeSecTypesCount := int(msgDec.Int32())
+		if derivativeSecTypesCount < 0 {
+			msgDec.SetErr(fmt.Errorf("negative derivative security types count: %d", derivativeSecTypesCount))
+			return msgDec.Err()
+		}
+		cd.DerivativeSecTypes = make([]models.SecurityType, 0, derivativeSecTypesCount)
+		for j := 0; j < derivativeSecTypesCount; j++ {
+			derivativeSecType := models.NewSecurityTypeFromString(msgDec.String())
+			cd.DerivativeSecTypes = append(cd.DerivativeSecTypes, derivativeSecType)
+		}
+		cd.Contract.Description = msgDec.String()
+		cd.Contract.IssuerID = msgDec.String()
+
+		cds = append(cds, cd)
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-func (c *Client) processPositionMultiMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processSymbolSamplesCommon(reqID, cds)
+}
 
-		msgDec.decode() // version
+func (c *Client) processSymbolSamplesCommon(reqID int32, cds []*models.ContractDescription) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.MatchingSymbolsResponse)
 
-		reqID := msgDec.decodeInt64()
-		account := msgDec.decodeString()
+		resp.ContractDescriptions = append(resp.ContractDescriptions, cds...)
+
+		// Done
+		return true, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processTickReqParamsMsg(msgDec *message.Decoder) error {
+	// Gets the originating ticker ID
+	tickerID := msgDec.RequestID(false)
+	params := models.NewTopMarketDataRequestParams()
+	params.MinTick = msgDec.FloatMax()
+	params.BBOExchange = msgDec.String()
+	params.SnapshotPermissions = msgDec.Int32()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processTickReqParamsCommon(tickerID, params)
+}
+
+func (c *Client) processTickReqParamsProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.TickReqParams{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating ticker ID
+	tickerID := msgDec.RequestID(pb.ReqId, false)
+	params := models.NewTopMarketDataRequestParams()
+	params.MinTick = msgDec.FloatMaxFromString(pb.MinTick)
+	params.BBOExchange = msgDec.String(pb.BboExchange)
+	params.SnapshotPermissions = msgDec.Int32(pb.SnapshotPermissions)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processTickReqParamsCommon(tickerID, params)
+}
 
-		// decode contract fields
-		contract := &Contract{}
-		contract.ConID = msgDec.decodeInt64()
-		contract.Symbol = msgDec.decodeString()
-		contract.SecType = msgDec.decodeString()
-		contract.LastTradeDateOrContractMonth = msgDec.decodeString()
-		contract.Strike = msgDec.decodeFloat64()
-		contract.Right = msgDec.decodeString()
-		contract.Multiplier = msgDec.decodeString()
-		contract.Exchange = msgDec.decodeString()
-		contract.Currency = msgDec.decodeString()
-		contract.LocalSymbol = msgDec.decodeString()
-		contract.TradingClass = msgDec.decodeString()
+func (c *Client) processTickReqParamsCommon(tickerID int32, params *models.TopMarketDataRequestParams) error {
+	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
+		if resp, ok := _resp.(*models.TopMarketDataResponse); ok {
+			// Notify without blocking the data stream if nobody is reading the parameters
+			select {
+			case resp.RequestParams <- params:
+			default:
+			}
+		}
 
-		pos := msgDec.decodeDecimal()
+		// Done
+		return false, nil
+	})
 
-		avgCost := msgDec.decodeFloat64()
-		modelCode := msgDec.decodeString()
+	// Done
+	return nil
+}
 
-		d.wrapper.PositionMulti(reqID, account, modelCode, contract, pos, avgCost)
+func (c *Client) processTickNewsMsg(msgDec *message.Decoder) error {
+	// Gets the originating ticker ID
+	tickerID := msgDec.RequestID(false)
+	data := models.NewTopMarketDataNewsFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processPositionMultiEndMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processTickNewsCommon(tickerID, data)
+}
 
-		msgDec.decode() // version
+func (c *Client) processTickNewsCommon(tickerID int32, data *models.TopMarketDataNews) error {
+	c.reqMgr.withRequestWithID(tickerID, func(_resp interface{}) (bool, error) {
+		resp, ok := _resp.(*models.TopMarketDataResponse)
+		if !ok {
+			// Ignore ticks of other requests, i.e.: option computations
+			return false, nil
+		}
 
-		reqID := msgDec.decodeInt64()
+		// Notify
+		resp.Channel <- data
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
 
-		d.wrapper.PositionMultiEnd(reqID)
+func (c *Client) processNewsProvidersMsg(msgDec *message.Decoder) error {
+	providersCount := int(msgDec.Int32())
+	if providersCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative news providers count: %d", providersCount))
+		return msgDec.Err()
+	}
+	providers := make([]models.NewsProvider, 0, providersCount)
+	for i := 0; i < providersCount; i++ {
+		providers = append(providers, models.NewNewsProviderFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processAccountUpdateMultiMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processNewsProvidersCommon(providers)
+}
 
-		msgDec.decode() // version
+func (c *Client) processNewsProvidersCommon(providers []models.NewsProvider) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_NEWS_PROVIDERS, func(_resp interface{}) error {
+		resp := _resp.(*models.NewsProvidersResponse)
 
-		reqID := msgDec.decodeInt64()
-		account := msgDec.decodeString()
-		modelCode := msgDec.decodeString()
-		key := msgDec.decodeString()
-		value := msgDec.decodeString()
-		currency := msgDec.decodeString()
+		resp.Providers = providers
+
+		// Done
+		return nil
+	})
+
+	// Done
+	return nil
+}
 
-		d.wrapper.AccountUpdateMulti(reqID, account, modelCode, key, value, currency)
+func (c *Client) processNewsArticleMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	article := models.NewNewsArticleFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processAccountUpdateMultiEndMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processNewsArticleCommon(reqID, article)
+}
 
-		msgDec.decode() // version
+func (c *Client) processNewsArticleCommon(reqID int32, article *models.NewsArticle) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.NewsArticleResponse)
 
-		reqID := msgDec.decodeInt64()
+		resp.Article = article
+
+		// Done
+		return true, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processHistoricalNewsMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	news := models.NewHistoricalNewsFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processHistoricalNewsCommon(reqID, news)
+}
+
+func (c *Client) processHistoricalNewsCommon(reqID int32, news *models.HistoricalNews) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.HistoricalNewsResponse)
+
+		resp.News = append(resp.News, news)
+
+		// Done
+		return false, nil
+	})
+
+	// Done
+	return nil
+}
+
+func (c *Client) processHistoricalNewsEndMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	hasMore := msgDec.Bool()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processHistoricalNewsEndCommon(reqID, hasMore)
+}
+
+func (c *Client) processHistoricalNewsEndCommon(reqID int32, hasMore bool) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.HistoricalNewsResponse)
+
+		resp.HasMore = hasMore
+
+		// Done
+		return true, nil
+	})
+
+	// Done
+	return nil
+}
 
-		d.wrapper.AccountUpdateMultiEnd(reqID)
+func (c *Client) processNewsBulletinsMsg(msgDec *message.Decoder) error {
+	bulletin := models.NewNewsBulletinFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processSecurityDefinitionOptionalParameterMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processNewsBulletinsCommon(bulletin)
+}
+
+func (c *Client) processNewsBulletinsCommon(bulletin *models.NewsBulletin) error {
+	c.reqMgr.withActiveRequestWithoutID(common.REQ_NEWS_BULLETINS, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.NewsBulletinsResponse)
+
+		// Notify
+		resp.Channel <- bulletin
 
-	reqID := msgDec.decodeInt64()
+		// Done
+		return false, nil
+	})
 
-	exchange := msgDec.decodeString()
-	underlyingConID := msgDec.decodeInt64()
-	tradingClass := msgDec.decodeString()
-	multiplier := msgDec.decodeString()
+	// Done
+	return nil
+}
 
-	expCount := msgDec.decodeInt64()
-	expirations := make([]string, 0, expCount)
-	var i int64
-	for i = 0; i < expCount; i++ {
-		expiration := msgDec.decodeString()
-		expirations = append(expirations, expiration)
+func (c *Client) processSecurityDefinitionOptionalParameterMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	params := models.NewOptionChainParamsFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	strikeCount := msgDec.decodeInt64()
-	strikes := make([]float64, 0, strikeCount)
-	for i = 0; i < strikeCount; i++ {
-		strike := msgDec.decodeFloat64()
-		strikes = append(strikes, strike)
-	}
+	// Done
+	return c.processSecurityDefinitionOptionalParameterCommon(reqID, params)
+}
 
-	d.wrapper.SecurityDefinitionOptionParameter(reqID, exchange, underlyingConID, tradingClass, multiplier, expirations, strikes)
+func (c *Client) processSecurityDefinitionOptionalParameterCommon(reqID int32, params *models.OptionChainParams) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.OptionChainParamsResponse)
 
-}
+		resp.Params = append(resp.Params, params)
 
-func (c *Client) processSecurityDefinitionOptionalParameterEndMsg(msgDec *utils.Decoder) error {
+		// Done
+		return false, nil
+	})
 
-		reqID := msgDec.decodeInt64()
+	// Done
+	return nil
+}
 
-		d.wrapper.SecurityDefinitionOptionParameterEnd(reqID)
+func (c *Client) processSecurityDefinitionOptionalParameterEndMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processSoftDollarTiersMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processSecurityDefinitionOptionalParameterEndCommon(reqID)
+}
 
-		reqID := msgDec.decodeInt64()
+func (c *Client) processSecurityDefinitionOptionalParameterEndCommon(reqID int32) error {
+	c.reqMgr.withRequestWithID(reqID, func(_ interface{}) (bool, error) {
+		// Done
+		return true, nil
+	})
 
-		tiersCount := msgDec.decodeInt64()
-		tiers := make([]SoftDollarTier, 0, tiersCount)
-		var i int64
-		for i = 0; i < tiersCount; i++ {
-			tier := NewSoftDollarTier()
-			tier.Name = msgDec.decodeString()
-			tier.Value = msgDec.decodeString()
-			tier.DisplayName = msgDec.decodeString()
-			tiers = append(tiers, tier)
-		}
+	// Done
+	return nil
+}
 
-		d.wrapper.SoftDollarTiers(reqID, tiers)
+func (c *Client) processSoftDollarTiersMsg(msgDec *message.Decoder) error {
+	_ = msgDec.RequestID(true) // Requests are sent with ID 0 and matched by message code
+	tiersCount := int(msgDec.Int32())
+	if tiersCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative soft dollar tiers count: %d", tiersCount))
+		return msgDec.Err()
+	}
+	tiers := make([]models.SoftDollarTier, 0, tiersCount)
+	for i := 0; i < tiersCount; i++ {
+		tiers = append(tiers, models.NewSoftDollarTierFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-func (c *Client) processFamilyCodesMsg(msgDec *utils.Decoder) error {
+	// Done
+	return c.processSoftDollarTiersCommon(tiers)
+}
+
+func (c *Client) processSoftDollarTiersCommon(tiers []models.SoftDollarTier) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_SOFT_DOLLAR_TIERS, func(_resp interface{}) error {
+		resp := _resp.(*models.SoftDollarTiersResponse)
 
-		familyCodesCount := msgDec.decodeInt64()
-		familyCodes := make([]FamilyCode, 0, familyCodesCount)
-		var i int64
-		for i = 0; i < familyCodesCount; i++ {
-			familyCode := NewFamilyCode()
-			familyCode.AccountID = msgDec.decodeString()
-			familyCode.FamilyCodeStr = msgDec.decodeString()
-			familyCodes = append(familyCodes, familyCode)
-		}
+		resp.Tiers = tiers
 
-		d.wrapper.FamilyCodes(familyCodes)
-	}
-*/
+		// Done
+		return nil
+	})
 
-func (c *Client) processSymbolSamplesMsg(msgDec *message.Decoder) error {
-	// Gets the originating request ID
-	reqID := msgDec.RequestID(false)
-	contractDescriptionsCount := int(msgDec.Int32())
-	if contractDescriptionsCount < 0 {
-		msgDec.SetErr(fmt.Errorf("negative contract descriptions count: %d", contractDescriptionsCount))
+	// Done
+	return nil
+}
+
+func (c *Client) processFamilyCodesMsg(msgDec *message.Decoder) error {
+	familyCodesCount := int(msgDec.Int32())
+	if familyCodesCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative family codes count: %d", familyCodesCount))
 		return msgDec.Err()
 	}
-	cds := make([]*models.ContractDescription, 0, contractDescriptionsCount)
-	for i := 0; i < contractDescriptionsCount; i++ {
-		cd := models.NewContractDescription()
-		cd.Contract.ConID = msgDec.Int32()
-		cd.Contract.Symbol = msgDec.String()
-		cd.Contract.SecType = models.NewSecurityTypeFromString(msgDec.String())
-		cd.Contract.PrimaryExchange = msgDec.String()
-		cd.Contract.Currency = msgDec.String()
-		derivativeSecTypesCount := int(msgDec.Int32())
-		if derivativeSecTypesCount < 0 {
-			msgDec.SetErr(fmt.Errorf("negative derivative security types count: %d", derivativeSecTypesCount))
-			return msgDec.Err()
-		}
-		cd.DerivativeSecTypes = make([]models.SecurityType, 0, derivativeSecTypesCount)
-		for j := 0; j < derivativeSecTypesCount; j++ {
-			derivativeSecType := models.NewSecurityTypeFromString(msgDec.String())
-			cd.DerivativeSecTypes = append(cd.DerivativeSecTypes, derivativeSecType)
-		}
-		cd.Contract.Description = msgDec.String()
-		cd.Contract.IssuerID = msgDec.String()
-
-		cds = append(cds, cd)
+	familyCodes := make([]models.FamilyCode, 0, familyCodesCount)
+	for i := 0; i < familyCodesCount; i++ {
+		familyCodes = append(familyCodes, models.NewFamilyCodeFromMessageDecoder(msgDec))
 	}
 	if msgDec.Err() != nil {
 		return msgDec.Err()
 	}
 
 	// Done
-	return c.processSymbolSamplesCommon(reqID, cds)
+	return c.processFamilyCodesCommon(familyCodes)
 }
 
-func (c *Client) processSymbolSamplesCommon(reqID int32, cds []*models.ContractDescription) error {
-	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
-		resp := _resp.(*models.MatchingSymbolsResponse)
+func (c *Client) processFamilyCodesCommon(familyCodes []models.FamilyCode) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_FAMILY_CODES, func(_resp interface{}) error {
+		resp := _resp.(*models.FamilyCodesResponse)
 
-		resp.ContractDescriptions = append(resp.ContractDescriptions, cds...)
+		resp.FamilyCodes = familyCodes
 
 		// Done
-		return true, nil
+		return nil
 	})
 
 	// Done
 	return nil
 }
 
-/*
-func (c *Client) processMktDepthExchangesMsg(msgDec *utils.Decoder) error {
-
-	depthMktDataDescriptionsCount := msgDec.decodeInt64()
-	depthMktDataDescriptions := make([]DepthMktDataDescription, 0, depthMktDataDescriptionsCount)
-
-	var i int64
-	for i = 0; i < depthMktDataDescriptionsCount; i++ {
-		desc := NewDepthMktDataDescription()
-		desc.Exchange = msgDec.decodeString()
-		desc.SecType = msgDec.decodeString()
-		if d.serverVersion >= MIN_SERVER_VER_SERVICE_DATA_TYPE {
-			desc.ListingExch = msgDec.decodeString()
-			desc.SecType = msgDec.decodeString()
-			desc.AggGroup = msgDec.decodeInt64()
-		} else {
-			_ = msgDec.decodeInt64() // boolean notSuppIsL2
-		}
-
-		depthMktDataDescriptions = append(depthMktDataDescriptions, desc)
+func (c *Client) processUserInfoMsg(msgDec *message.Decoder) error {
+	_ = msgDec.RequestID(true) // Requests are sent with ID 0 and matched by message code
+	whiteBrandingID := msgDec.String()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	d.wrapper.MktDepthExchanges(depthMktDataDescriptions)
+	// Done
+	return c.processUserInfoCommon(whiteBrandingID)
 }
 
-func (c *Client) processTickNewsMsg(msgDec *utils.Decoder) error {
+func (c *Client) processUserInfoCommon(whiteBrandingID string) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_USER_INFO, func(_resp interface{}) error {
+		resp := _resp.(*models.UserInfoResponse)
 
-	tickerID := msgDec.decodeInt64()
+		resp.WhiteBrandingID = whiteBrandingID
 
-	timeStamp := msgDec.decodeInt64()
-	providerCode := msgDec.decodeString()
-	articleID := msgDec.decodeString()
-	headline := msgDec.decodeString()
-	extraData := msgDec.decodeString()
+		// Done
+		return nil
+	})
 
-	d.wrapper.TickNews(tickerID, timeStamp, providerCode, articleID, headline, extraData)
+	// Done
+	return nil
 }
 
-func (c *Client) processTickReqParamsMsg(msgDec *utils.Decoder) error {
-
-	tickerID := msgDec.decodeInt64()
-
-	minTick := msgDec.decodeFloat64()
-	bboExchange := msgDec.decodeString()
-	snapshotPermissions := msgDec.decodeInt64()
+func (c *Client) processMktDepthExchangesMsg(msgDec *message.Decoder) error {
+	descriptionsCount := int(msgDec.Int32())
+	if descriptionsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative market depth exchanges count: %d", descriptionsCount))
+		return msgDec.Err()
+	}
+	descriptions := make([]*models.DepthMktDataDescription, 0, descriptionsCount)
+	for i := 0; i < descriptionsCount; i++ {
+		descriptions = append(descriptions, models.NewDepthMktDataDescriptionFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	d.wrapper.TickReqParams(tickerID, minTick, bboExchange, snapshotPermissions)
+	// Done
+	return c.processMktDepthExchangesCommon(descriptions)
 }
 
-func (c *Client) processSmartComponentsMsg(msgDec *utils.Decoder) error {
+func (c *Client) processMktDepthExchangesCommon(descriptions []*models.DepthMktDataDescription) error {
+	c.reqMgr.withRequestWithoutID(common.REQ_MKT_DEPTH_EXCHANGES, func(_resp interface{}) error {
+		resp := _resp.(*models.MarketDepthExchangesResponse)
 
-	reqID := msgDec.decodeInt64()
+		resp.Exchanges = descriptions
 
-	smartComponentsCount := msgDec.decodeInt64()
-	smartComponents := make([]SmartComponent, 0, smartComponentsCount)
-	var i int64
-	for i = 0; i < smartComponentsCount; i++ {
-		smartComponent := NewSmartComponent()
-		smartComponent.BitNumber = msgDec.decodeInt64()
-		smartComponent.Exchange = msgDec.decodeString()
-		smartComponent.ExchangeLetter = msgDec.decodeString()
-		smartComponents = append(smartComponents, smartComponent)
-	}
+		// Done
+		return nil
+	})
 
-	d.wrapper.SmartComponents(reqID, smartComponents)
+	// Done
+	return nil
 }
 
-func (c *Client) processNewsProvidersMsg(msgDec *utils.Decoder) error {
-
-	newsProvidersCount := msgDec.decodeInt64()
-	newsProviders := make([]NewsProvider, 0, newsProvidersCount)
-	var i int64
-	for i = 0; i < newsProvidersCount; i++ {
-		provider := NewNewsProvider()
-		provider.Name = msgDec.decodeString()
-		provider.Code = msgDec.decodeString()
-		newsProviders = append(newsProviders, provider)
+func (c *Client) processSmartComponentsMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	componentsCount := int(msgDec.Int32())
+	if componentsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative smart components count: %d", componentsCount))
+		return msgDec.Err()
+	}
+	components := make([]models.SmartComponent, 0, componentsCount)
+	for i := 0; i < componentsCount; i++ {
+		components = append(components, models.NewSmartComponentFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	d.wrapper.NewsProviders(newsProviders)
+	// Done
+	return c.processSmartComponentsCommon(reqID, components)
 }
 
-func (c *Client) processNewsArticleMsg(msgDec *utils.Decoder) error {
+func (c *Client) processSmartComponentsCommon(reqID int32, components []models.SmartComponent) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.SmartComponentsResponse)
 
-	reqID := msgDec.decodeInt64()
+		resp.Components = components
 
-	articleType := msgDec.decodeInt64()
-	articleText := msgDec.decodeString()
+		// Done
+		return true, nil
+	})
 
-	d.wrapper.NewsArticle(reqID, articleType, articleText)
+	// Done
+	return nil
 }
 
-func (c *Client) processHistoricalNewsMsg(msgDec *utils.Decoder) error {
-
-	requestID := msgDec.decodeInt64()
-
-	time := msgDec.decodeString()
-	providerCode := msgDec.decodeString()
-	articleID := msgDec.decodeString()
-	headline := msgDec.decodeString()
+func (c *Client) processReplaceFAEndMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	text := msgDec.String()
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	d.wrapper.HistoricalNews(requestID, time, providerCode, articleID, headline)
+	// Done
+	return c.processReplaceFAEndCommon(reqID, text)
 }
 
-func (c *Client) processHistoricalNewsEndMsg(msgDec *utils.Decoder) error {
+func (c *Client) processReplaceFAEndCommon(reqID int32, text string) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.ReplaceFaResponse)
 
-	requestID := msgDec.decodeInt64()
+		resp.Text = text
 
-	hasMore := msgDec.decodeBool()
+		// Done
+		return true, nil
+	})
 
-	d.wrapper.HistoricalNewsEnd(requestID, hasMore)
+	// Done
+	return nil
 }
-*/
 
 func (c *Client) processHeadTimestampMsg(msgDec *message.Decoder) error {
 	// Gets the originating ticker ID
@@ -2353,24 +3143,58 @@ func (c *Client) processHeadTimestampCommon(reqID int32, ts time.Time) error {
 	return nil
 }
 
-/*
-func (c *Client) processHistogramDataMsg(msgDec *utils.Decoder) error {
+func (c *Client) processHistogramDataMsg(msgDec *message.Decoder) error {
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	count := int(msgDec.Int32())
+	if count < 0 {
+		msgDec.SetErr(fmt.Errorf("negative histogram entries count: %d", count))
+		return msgDec.Err()
+	}
+	data := make([]models.HistogramData, 0, count)
+	for i := 0; i < count; i++ {
+		data = append(data, models.NewHistogramDataFromMessageDecoder(msgDec))
+	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	reqID := msgDec.decodeInt64()
+	// Done
+	return c.processHistogramDataCommon(reqID, data)
+}
 
-	numPoints := msgDec.decodeInt64()
-	data := make([]HistogramData, 0, numPoints)
-	var i int64
-	for i = 0; i < numPoints; i++ {
-		p := HistogramData{}
-		p.Price = msgDec.decodeFloat64()
-		p.Size = msgDec.decodeDecimal()
-		data = append(data, p)
+func (c *Client) processHistogramDataProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.HistogramData{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	data := make([]models.HistogramData, 0, len(pb.HistogramDataEntries))
+	for _, entry := range pb.HistogramDataEntries {
+		data = append(data, models.NewHistogramDataFromProtobufDecoder(msgDec, entry))
 	}
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
+
+	// Done
+	return c.processHistogramDataCommon(reqID, data)
+}
+
+func (c *Client) processHistogramDataCommon(reqID int32, data []models.HistogramData) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.HistogramDataResponse)
 
-	d.wrapper.HistogramData(reqID, data)
+		resp.Data = data
+
+		// Done
+		return true, nil
+	})
+
+	// Done
+	return nil
 }
 
+/*
 func (c *Client) processRerouteMktDataReqMsg(msgDec *utils.Decoder) error {
 
 	reqID := msgDec.decodeInt64()
@@ -2390,66 +3214,67 @@ func (c *Client) processRerouteMktDepthReqMsg(msgDec *utils.Decoder) error {
 
 	d.wrapper.RerouteMktDepthReq(reqID, conID, exchange)
 }
+*/
 
-func (c *Client) processMarketRuleMsg(msgDec *utils.Decoder) error {
-
-	marketRuleID := msgDec.decodeInt64()
-
-	priceIncrementsCount := msgDec.decodeInt64()
-	priceIncrements := make([]PriceIncrement, 0, priceIncrementsCount)
-
-	var i int64
-	for i = 0; i < priceIncrementsCount; i++ {
-		priceInc := NewPriceIncrement()
-		priceInc.LowEdge = msgDec.decodeFloat64()
-		priceInc.Increment = msgDec.decodeFloat64()
-		priceIncrements = append(priceIncrements, priceInc)
+func (c *Client) processMarketRuleMsg(msgDec *message.Decoder) error {
+	rule := models.NewMarketRuleFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	d.wrapper.MarketRule(marketRuleID, priceIncrements)
+	// Done
+	return c.processMarketRuleCommon(rule)
 }
 
-func (c *Client) processPnLMsg(msgDec *utils.Decoder) error {
-
-	reqID := msgDec.decodeInt64()
-
-	dailyPnL := msgDec.decodeFloat64()
-	var unrealizedPnL float64
-	var realizedPnL float64
+func (c *Client) processMarketRuleCommon(rule *models.MarketRule) error {
+	// Cache the rule and notify all the requests waiting for it
+	c.marketRules.set(rule)
 
-	if d.serverVersion >= MIN_SERVER_VER_UNREALIZED_PNL {
-		unrealizedPnL = msgDec.decodeFloat64()
-	}
+	// Done
+	return nil
+}
 
-	if d.serverVersion >= MIN_SERVER_VER_REALIZED_PNL {
-		realizedPnL = msgDec.decodeFloat64()
+func (c *Client) processRealTimeBarsMsg(msgDec *message.Decoder) error {
+	msgDec.Skip() // version
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(false)
+	bar := models.NewRealTimeBarFromMessageDecoder(msgDec)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
 	}
 
-	d.wrapper.Pnl(reqID, dailyPnL, unrealizedPnL, realizedPnL)
+	// Done
+	return c.processRealTimeBarsCommon(reqID, bar)
 }
 
-func (c *Client) processPnLSingleMsg(msgDec *utils.Decoder) error {
-
-	reqID := msgDec.decodeInt64()
+func (c *Client) processRealTimeBarsProtobuf(msgDec *protofmt.Decoder) error {
+	pb := protobuf.RealTimeBarTick{}
+	msgDec.Unmarshal(&pb)
+	// Gets the originating request ID
+	reqID := msgDec.RequestID(pb.ReqId, false)
+	bar := models.NewRealTimeBarFromProtobufDecoder(msgDec, &pb)
+	if msgDec.Err() != nil {
+		return msgDec.Err()
+	}
 
-	pos := msgDec.decodeDecimal()
-	dailyPnL := msgDec.decodeFloat64()
-	var unrealizedPnL float64
-	var realizedPnL float64
+	// Done
+	return c.processRealTimeBarsCommon(reqID, bar)
+}
 
-	if d.serverVersion >= MIN_SERVER_VER_UNREALIZED_PNL {
-		unrealizedPnL = msgDec.decodeFloat64()
-	}
+func (c *Client) processRealTimeBarsCommon(reqID int32, bar models.RealTimeBar) error {
+	c.reqMgr.withRequestWithID(reqID, func(_resp interface{}) (bool, error) {
+		resp := _resp.(*models.RealTimeBarsResponse)
 
-	if d.serverVersion >= MIN_SERVER_VER_REALIZED_PNL {
-		realizedPnL = msgDec.decodeFloat64()
-	}
+		// Notify
+		resp.Channel <- bar
 
-	value := msgDec.decodeFloat64()
+		// Done
+		return false, nil
+	})
 
-	d.wrapper.PnlSingle(reqID, pos, dailyPnL, unrealizedPnL, realizedPnL, value)
+	// Done
+	return nil
 }
-*/
 
 func (c *Client) processHistoricalTicksMsg(msgDec *message.Decoder) error {
 	// Gets the originating request ID
@@ -2639,49 +3464,6 @@ func (c *Client) processHistoricalTicksLastCommon(reqID int32, ticks []models.Hi
 }
 
 /*
-func (c *Client) processTickByTickDataMsg(msgDec *utils.Decoder) error {
-
-		reqID := msgDec.decodeInt64()
-
-		tickType := msgDec.decodeInt64()
-		time := msgDec.decodeInt64()
-
-		switch tickType {
-		case 0: // None
-		case 1, 2: // Last or AllLast
-			price := msgDec.decodeFloat64()
-			size := msgDec.decodeDecimal()
-			mask := msgDec.decodeInt64()
-
-			tickAttribLast := NewTickAttribLast()
-			tickAttribLast.PastLimit = mask&1 != 0
-			tickAttribLast.Unreported = mask&2 != 0
-
-			exchange := msgDec.decodeString()
-			specialConditions := msgDec.decodeString()
-
-			d.wrapper.TickByTickAllLast(reqID, tickType, time, price, size, tickAttribLast, exchange, specialConditions)
-
-		case 3: // BidAsk
-			bidPrice := msgDec.decodeFloat64()
-			askPrice := msgDec.decodeFloat64()
-			bidSize := msgDec.decodeDecimal()
-			askSize := msgDec.decodeDecimal()
-			mask := msgDec.decodeInt64()
-
-			tickAttribBidAsk := NewTickAttribBidAsk()
-			tickAttribBidAsk.BidPastLow = mask&1 != 0
-			tickAttribBidAsk.AskPastHigh = mask&2 != 0
-
-			d.wrapper.TickByTickBidAsk(reqID, time, bidPrice, askPrice, bidSize, askSize, tickAttribBidAsk)
-
-		case 4: // MidPoint
-			midPoint := msgDec.decodeFloat64()
-
-			d.wrapper.TickByTickMidPoint(reqID, time, midPoint)
-		}
-	}
-
 func (c *Client) processOrderBoundMsg(msgDec *utils.Decoder) error {
 
 		permID := msgDec.decodeInt64()
@@ -2691,98 +3473,6 @@ func (c *Client) processOrderBoundMsg(msgDec *utils.Decoder) error {
 		d.wrapper.OrderBound(permID, clientId, orderId)
 	}
 
-func (c *Client) processCompletedOrderMsg(msgDec *utils.Decoder) error {
-
-		order := NewOrder()
-		contract := NewContract()
-		orderState := NewOrderState()
-
-		orderDecoder := &OrderDecoder{order, contract, orderState, Version(UNSET_INT), d.serverVersion}
-
-		// read contract fields
-		orderDecoder.decodeContractFields(msgDec)
-
-		// read order fields
-		orderDecoder.decodeAction(msgDec)
-		orderDecoder.decodeTotalQuantity(msgDec)
-		orderDecoder.decodeOrderType(msgDec)
-		orderDecoder.decodeLmtPrice(msgDec)
-		orderDecoder.decodeAuxPrice(msgDec)
-		orderDecoder.decodeTIF(msgDec)
-		orderDecoder.decodeOcaGroup(msgDec)
-		orderDecoder.decodeAccount(msgDec)
-		orderDecoder.decodeOpenClose(msgDec)
-		orderDecoder.decodeOrigin(msgDec)
-		orderDecoder.decodeOrderRef(msgDec)
-		orderDecoder.decodePermId(msgDec)
-		orderDecoder.decodeOutsideRth(msgDec)
-		orderDecoder.decodeHidden(msgDec)
-		orderDecoder.decodeDiscretionaryAmount(msgDec)
-		orderDecoder.decodeGoodAfterTime(msgDec)
-		orderDecoder.decodeFAParams(msgDec)
-		orderDecoder.decodeModelCode(msgDec)
-		orderDecoder.decodeGoodTillDate(msgDec)
-		orderDecoder.decodeRule80A(msgDec)
-		orderDecoder.decodePercentOffset(msgDec)
-		orderDecoder.decodeSettlingFirm(msgDec)
-		orderDecoder.decodeShortSaleParams(msgDec)
-		orderDecoder.decodeBoxOrderParams(msgDec)
-		orderDecoder.decodePegToStkOrVolOrderParams(msgDec)
-		orderDecoder.decodeDisplaySize(msgDec)
-		orderDecoder.decodeSweepToFill(msgDec)
-		orderDecoder.decodeAllOrNone(msgDec)
-		orderDecoder.decodeMinQty(msgDec)
-		orderDecoder.decodeOcaType(msgDec)
-		orderDecoder.decodeTriggerMethod(msgDec)
-		orderDecoder.decodeVolOrderParams(msgDec, false)
-		orderDecoder.decodeTrailParams(msgDec)
-		orderDecoder.decodeComboLegs(msgDec)
-		orderDecoder.decodeSmartComboRoutingParams(msgDec)
-		orderDecoder.decodeScaleOrderParams(msgDec)
-		orderDecoder.decodeHedgeParams(msgDec)
-		orderDecoder.decodeClearingParams(msgDec)
-		orderDecoder.decodeNotHeld(msgDec)
-		orderDecoder.decodeDeltaNeutral(msgDec)
-		orderDecoder.decodeAlgoParams(msgDec)
-		orderDecoder.decodeSolicited(msgDec)
-		orderDecoder.decodeOrderStatus(msgDec)
-		orderDecoder.decodeVolRandomizeFlags(msgDec)
-		orderDecoder.decodePegBenchParams(msgDec)
-		orderDecoder.decodeConditions(msgDec)
-		orderDecoder.decodeStopPriceAndLmtPriceOffset(msgDec)
-		orderDecoder.decodeCashQty(msgDec)
-		orderDecoder.decodeDontUseAutoPriceForHedge(msgDec)
-		orderDecoder.decodeIsOmsContainer(msgDec)
-		orderDecoder.decodeAutoCancelDate(msgDec)
-		orderDecoder.decodeFilledQuantity(msgDec)
-		orderDecoder.decodeRefFuturesConId(msgDec)
-		orderDecoder.decodeAutoCancelParent(msgDec, MIN_CLIENT_VER)
-		orderDecoder.decodeShareholder(msgDec)
-		orderDecoder.decodeImbalanceOnly(msgDec, MIN_CLIENT_VER)
-		orderDecoder.decodeRouteMarketableToBbo(msgDec)
-		orderDecoder.decodeParentPermId(msgDec)
-		orderDecoder.decodeCompletedTime(msgDec)
-		orderDecoder.decodeCompletedStatus(msgDec)
-		orderDecoder.decodePegBestPegMidOrderAttributes(msgDec)
-		orderDecoder.decodeCustomerAccount(msgDec)
-		orderDecoder.decodeProfessionalCustomer(msgDec)
-		orderDecoder.decodeSubmitter(msgDec)
-
-		d.wrapper.CompletedOrder(contract, order, orderState)
-	}
-
-	func (c *Client) processCompletedOrdersEndMsg(*msgDecfer) {
-		d.wrapper.CompletedOrdersEnd()
-	}
-
-func (c *Client) processReplaceFAEndMsg(msgDec *utils.Decoder) error {
-
-		reqID := msgDec.decodeInt64()
-		text := msgDec.decodeString()
-
-		d.wrapper.ReplaceFAEnd(reqID, text)
-	}
-
 func (c *Client) processWshMetaData(msgDec *utils.Decoder) error {
 
 		reqID := msgDec.decodeInt64()
@@ -2818,14 +3508,6 @@ func (c *Client) processHistoricalSchedule(msgDec *utils.Decoder) error {
 
 		d.wrapper.HistoricalSchedule(reqID, startDateTime, endDateTime, timeZone, sessions)
 	}
-
-func (c *Client) processUserInfo(msgDec *utils.Decoder) error {
-
-		reqID := msgDec.decodeInt64()
-		whiteBrandingId := msgDec.decodeString()
-
-		d.wrapper.UserInfo(reqID, whiteBrandingId)
-	}
 */
 
 func (c *Client) processCurrentTimeInMillisMsg(msgDec *message.Decoder) error {
@@ -2850,3 +3532,30 @@ func (c *Client) processCurrentTimeInMillisCommon(ts time.Time) error {
 	// Done
 	return nil
 }
+
+// isFinalOrderError returns true if the error code is an order rejection after which no status update will follow.
+func isFinalOrderError(code int) bool {
+	switch code {
+	case 103, // Duplicate order ID
+		110,   // The price does not conform to the minimum price variation
+		200,   // No security definition has been found
+		201,   // Order rejected
+		321,   // Error validating request
+		10147, // The order to cancel was not found
+		10148: // The order to cancel cannot be cancelled
+		return true
+	}
+	return false
+}
+
+// orderEventsChannel returns the channel where the events of a tracked order must be delivered, or false if the
+// response holder is not an order one.
+func orderEventsChannel(_resp interface{}) (chan models.OrderEvent, bool) {
+	switch resp := _resp.(type) {
+	case *models.PlaceOrderResponse:
+		return resp.Channel, true
+	case *models.ExerciseOptionsResponse:
+		return resp.Channel, true
+	}
+	return nil, false
+}
@@ -0,0 +1,85 @@
+package ibkr
+
+import (
+	"context"
+	"sync"
+
+	"github.com/mxmauro/ibkr/models"
+)
+
+// -----------------------------------------------------------------------------
+
+// marketRulesCache keeps the market rules already received. Rules rarely change, so they are kept for the lifetime
+// of the client.
+//
+// Market rule responses carry the rule ID instead of a request ID, so the requests waiting for a rule are also kept
+// here, keyed by the rule ID.
+type marketRulesCache struct {
+	mtx     sync.RWMutex
+	rules   map[int32]*models.MarketRule
+	waiters map[int32][]*Request
+}
+
+// -----------------------------------------------------------------------------
+
+func (c *Client) initMarketRulesCache() {
+	c.marketRules = marketRulesCache{
+		mtx:     sync.RWMutex{},
+		rules:   make(map[int32]*models.MarketRule),
+		waiters: make(map[int32][]*Request),
+	}
+}
+
+func (mrc *marketRulesCache) get(ruleID int32) (*models.MarketRule, bool) {
+	mrc.mtx.RLock()
+	defer mrc.mtx.RUnlock()
+
+	rule, ok := mrc.rules[ruleID]
+	return rule, ok
+}
+
+// set stores the rule and completes all the requests waiting for it.
+func (mrc *marketRulesCache) set(rule *models.MarketRule) {
+	mrc.mtx.Lock()
+	mrc.rules[rule.ID] = rule
+	waiters := mrc.waiters[rule.ID]
+	delete(mrc.waiters, rule.ID)
+	mrc.mtx.Unlock()
+
+	for _, req := range waiters {
+		req.responseMtx.Lock()
+		if resp, ok := req.response.(*models.MarketRuleResponse); ok {
+			resp.Rule = rule
+		}
+		req.responseMtx.Unlock()
+
+		req.complete(nil)
+	}
+}
+
+func (mrc *marketRulesCache) addWaiter(ruleID int32, req *Request) {
+	mrc.mtx.Lock()
+	defer mrc.mtx.Unlock()
+
+	mrc.waiters[ruleID] = append(mrc.waiters[ruleID], req)
+}
+
+// removeWaiter removes the request from the waiters of the rule and completes it if it is still pending.
+func (mrc *marketRulesCache) removeWaiter(ruleID int32, req *Request) {
+	mrc.mtx.Lock()
+	waiters := mrc.waiters[ruleID]
+	for idx, r := range waiters {
+		if r == req {
+			waiters = append(waiters[:idx], waiters[idx+1:]...)
+			break
+		}
+	}
+	if len(waiters) > 0 {
+		mrc.waiters[ruleID] = waiters
+	} else {
+		delete(mrc.waiters, ruleID)
+	}
+	mrc.mtx.Unlock()
+
+	req.complete(context.Canceled)
+}
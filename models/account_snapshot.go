@@ -0,0 +1,126 @@
+package models
+
+import (
+	"sync"
+)
+
+// -----------------------------------------------------------------------------
+
+// AccountSnapshot keeps the latest state of an account built from the updates of a subscription. It is safe to
+// access it while updates are being applied.
+type AccountSnapshot struct {
+	mtx        sync.RWMutex
+	values     map[accountValueKey]AccountValue
+	portfolio  map[int32]PortfolioItem
+	updateTime string
+	completeCh chan struct{}
+}
+
+type accountValueKey struct {
+	account  string
+	key      string
+	currency string
+}
+
+// -----------------------------------------------------------------------------
+
+func NewAccountSnapshot() *AccountSnapshot {
+	return &AccountSnapshot{
+		mtx:        sync.RWMutex{},
+		values:     make(map[accountValueKey]AccountValue),
+		portfolio:  make(map[int32]PortfolioItem),
+		completeCh: make(chan struct{}),
+	}
+}
+
+// Update applies the given update to the snapshot.
+func (s *AccountSnapshot) Update(upd AccountUpdate) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+
+	switch u := upd.(type) {
+	case *AccountValue:
+		s.values[accountValueKey{
+			account:  u.Account,
+			key:      u.Key,
+			currency: u.Currency,
+		}] = *u
+
+	case *PortfolioItem:
+		if u.Position.IsZero() {
+			delete(s.portfolio, u.Contract.ConID)
+		} else {
+			s.portfolio[u.Contract.ConID] = *u
+		}
+
+	case *AccountUpdateTime:
+		s.updateTime = u.Time
+
+	case *AccountDownloadEnd:
+		select {
+		case <-s.completeCh:
+		default:
+			close(s.completeCh)
+		}
+	}
+}
+
+// Value returns the account value with the given key and currency.
+func (s *AccountSnapshot) Value(account string, key string, currency string) (AccountValue, bool) {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	av, ok := s.values[accountValueKey{
+		account:  account,
+		key:      key,
+		currency: currency,
+	}]
+	return av, ok
+}
+
+// Values returns all the account values.
+func (s *AccountSnapshot) Values() []AccountValue {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	values := make([]AccountValue, 0, len(s.values))
+	for _, av := range s.values {
+		values = append(values, av)
+	}
+	return values
+}
+
+// Portfolio returns all the open positions.
+func (s *AccountSnapshot) Portfolio() []PortfolioItem {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	portfolio := make([]PortfolioItem, 0, len(s.portfolio))
+	for _, pi := range s.portfolio {
+		portfolio = append(portfolio, pi)
+	}
+	return portfolio
+}
+
+// UpdateTime returns the time of the last account update.
+func (s *AccountSnapshot) UpdateTime() string {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+
+	return s.updateTime
+}
+
+// IsComplete returns true once the initial download of the account data finished.
+func (s *AccountSnapshot) IsComplete() bool {
+	select {
+	case <-s.completeCh:
+		return true
+	default:
+	}
+	return false
+}
+
+// CompleteCh returns a channel that is closed once the initial download of the account data finished.
+func (s *AccountSnapshot) CompleteCh() <-chan struct{} {
+	return s.completeCh
+}
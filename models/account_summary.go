@@ -0,0 +1,118 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+type AccountSummaryTag string
+
+const (
+	AccountSummaryTagAccountType                    AccountSummaryTag = "AccountType"
+	AccountSummaryTagNetLiquidation                 AccountSummaryTag = "NetLiquidation"
+	AccountSummaryTagTotalCashValue                 AccountSummaryTag = "TotalCashValue"
+	AccountSummaryTagSettledCash                    AccountSummaryTag = "SettledCash"
+	AccountSummaryTagAccruedCash                    AccountSummaryTag = "AccruedCash"
+	AccountSummaryTagBuyingPower                    AccountSummaryTag = "BuyingPower"
+	AccountSummaryTagEquityWithLoanValue            AccountSummaryTag = "EquityWithLoanValue"
+	AccountSummaryTagPreviousDayEquityWithLoanValue AccountSummaryTag = "PreviousDayEquityWithLoanValue"
+	AccountSummaryTagGrossPositionValue             AccountSummaryTag = "GrossPositionValue"
+	AccountSummaryTagRegTEquity                     AccountSummaryTag = "RegTEquity"
+	AccountSummaryTagRegTMargin                     AccountSummaryTag = "RegTMargin"
+	AccountSummaryTagSMA                            AccountSummaryTag = "SMA"
+	AccountSummaryTagInitMarginReq                  AccountSummaryTag = "InitMarginReq"
+	AccountSummaryTagMaintMarginReq                 AccountSummaryTag = "MaintMarginReq"
+	AccountSummaryTagAvailableFunds                 AccountSummaryTag = "AvailableFunds"
+	AccountSummaryTagExcessLiquidity                AccountSummaryTag = "ExcessLiquidity"
+	AccountSummaryTagCushion                        AccountSummaryTag = "Cushion"
+	AccountSummaryTagFullInitMarginReq              AccountSummaryTag = "FullInitMarginReq"
+	AccountSummaryTagFullMaintMarginReq             AccountSummaryTag = "FullMaintMarginReq"
+	AccountSummaryTagFullAvailableFunds             AccountSummaryTag = "FullAvailableFunds"
+	AccountSummaryTagFullExcessLiquidity            AccountSummaryTag = "FullExcessLiquidity"
+	AccountSummaryTagLookAheadNextChange            AccountSummaryTag = "LookAheadNextChange"
+	AccountSummaryTagLookAheadInitMarginReq         AccountSummaryTag = "LookAheadInitMarginReq"
+	AccountSummaryTagLookAheadMaintMarginReq        AccountSummaryTag = "LookAheadMaintMarginReq"
+	AccountSummaryTagLookAheadAvailableFunds        AccountSummaryTag = "LookAheadAvailableFunds"
+	AccountSummaryTagLookAheadExcessLiquidity       AccountSummaryTag = "LookAheadExcessLiquidity"
+	AccountSummaryTagHighestSeverity                AccountSummaryTag = "HighestSeverity"
+	AccountSummaryTagDayTradesRemaining             AccountSummaryTag = "DayTradesRemaining"
+	AccountSummaryTagLeverage                       AccountSummaryTag = "Leverage"
+	AccountSummaryTagLedger                         AccountSummaryTag = "$LEDGER"          // Cash balances in the base currency
+	AccountSummaryTagLedgerAll                      AccountSummaryTag = "$LEDGER:ALL"      // Cash balances in all currencies
+	AccountSummaryTagLedgerCurrency                 AccountSummaryTag = "$LEDGER:CURRENCY" // Replace CURRENCY with a specific currency code (i.e.: $LEDGER:USD)
+)
+
+// AccountSummaryGroupAll selects all the accounts of the user.
+const AccountSummaryGroupAll = "All"
+
+// AccountSummaryValue is the value of an account summary tag.
+type AccountSummaryValue struct {
+	Value    string
+	Currency string
+}
+
+// AccountSummary contains the summary values keyed by account and tag. Ledger tags are expanded by the server into
+// several tags (i.e.: CashBalance), so the keys are plain strings.
+type AccountSummary map[string]map[string]AccountSummaryValue
+
+// AccountSummaryUpdate is a single value received for an account summary request.
+type AccountSummaryUpdate struct {
+	Account  string
+	Tag      string
+	Value    string
+	Currency string
+}
+
+// -----------------------------------------------------------------------------
+
+func (tag AccountSummaryTag) String() string {
+	return string(tag)
+}
+
+// NewAccountSummary creates an empty account summary.
+func NewAccountSummary() AccountSummary {
+	return make(AccountSummary)
+}
+
+// Update applies the given update to the summary.
+func (as AccountSummary) Update(upd AccountSummaryUpdate) {
+	tags, ok := as[upd.Account]
+	if !ok {
+		tags = make(map[string]AccountSummaryValue)
+		as[upd.Account] = tags
+	}
+	tags[upd.Tag] = AccountSummaryValue{
+		Value:    upd.Value,
+		Currency: upd.Currency,
+	}
+}
+
+func NewAccountSummaryUpdateFromMessageDecoder(msgDec *message.Decoder) AccountSummaryUpdate {
+	return AccountSummaryUpdate{
+		Account:  msgDec.String(),
+		Tag:      msgDec.String(),
+		Value:    msgDec.String(),
+		Currency: msgDec.String(),
+	}
+}
+
+func NewAccountSummaryUpdateFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.AccountSummary) AccountSummaryUpdate {
+	if pb == nil {
+		return AccountSummaryUpdate{}
+	}
+	return AccountSummaryUpdate{
+		Account:  msgDec.String(pb.Account),
+		Tag:      msgDec.String(pb.Tag),
+		Value:    msgDec.String(pb.Value),
+		Currency: msgDec.String(pb.Currency),
+	}
+}
+
+func (upd AccountSummaryUpdate) String() string {
+	return fmt.Sprintf("Account: %s, Tag: %s, Value: %s, Currency: %s", upd.Account, upd.Tag, upd.Value, upd.Currency)
+}
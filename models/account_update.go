@@ -0,0 +1,151 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+// AccountUpdate is an update received for an account updates subscription. It can be an *AccountValue, a
+// *PortfolioItem, an *AccountUpdateTime or an *AccountDownloadEnd.
+type AccountUpdate interface {
+	String() string
+
+	isAccountUpdate()
+}
+
+// AccountValue is a single account value (i.e.: NetLiquidation) of an account.
+type AccountValue struct {
+	Account  string
+	Key      string
+	Value    string
+	Currency string
+}
+
+// PortfolioItem is a position held in an account along with its valuation.
+type PortfolioItem struct {
+	Account       string
+	Contract      *Contract
+	Position      Decimal
+	MarketPrice   float64
+	MarketValue   float64
+	AverageCost   float64
+	UnrealizedPNL float64
+	RealizedPNL   float64
+}
+
+// AccountUpdateTime is the time of the last account update, in HH:MM format.
+type AccountUpdateTime struct {
+	Time string
+}
+
+// AccountDownloadEnd signals the initial download of the account data is complete.
+type AccountDownloadEnd struct {
+	Account string
+}
+
+// -----------------------------------------------------------------------------
+
+func NewAccountValueFromMessageDecoder(msgDec *message.Decoder) *AccountValue {
+	av := AccountValue{}
+	msgDec.Skip() // version
+	av.Key = msgDec.String()
+	av.Value = msgDec.String()
+	av.Currency = msgDec.String()
+	av.Account = msgDec.String()
+	return &av
+}
+
+func NewAccountValueFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.AccountValue) *AccountValue {
+	av := AccountValue{}
+	if pb == nil {
+		return &av
+	}
+	av.Key = msgDec.String(pb.Key)
+	av.Value = msgDec.String(pb.Value)
+	av.Currency = msgDec.String(pb.Currency)
+	av.Account = msgDec.String(pb.AccountName)
+	return &av
+}
+
+func (av *AccountValue) String() string {
+	return fmt.Sprintf("Account: %s, Key: %s, Value: %s, Currency: %s", av.Account, av.Key, av.Value, av.Currency)
+}
+
+func (av *AccountValue) isAccountUpdate() {}
+
+func NewPortfolioItemFromMessageDecoder(msgDec *message.Decoder) *PortfolioItem {
+	pi := PortfolioItem{
+		Contract: NewContract(),
+	}
+	msgDec.Skip() // version
+	pi.Contract.ConID = msgDec.Int32()
+	pi.Contract.Symbol = msgDec.String()
+	pi.Contract.SecType = NewSecurityTypeFromString(msgDec.String())
+	pi.Contract.LastTradeDateOrContractMonth = msgDec.String()
+	pi.Contract.Strike = msgDec.FloatMax()
+	pi.Contract.Right = msgDec.String()
+	pi.Contract.Multiplier = msgDec.FloatMax()
+	pi.Contract.PrimaryExchange = msgDec.String()
+	pi.Contract.Currency = msgDec.String()
+	pi.Contract.LocalSymbol = msgDec.String()
+	pi.Contract.TradingClass = msgDec.String()
+	pi.Position = NewDecimalFromMessageDecoder(msgDec)
+	pi.MarketPrice = msgDec.Float()
+	pi.MarketValue = msgDec.Float()
+	pi.AverageCost = msgDec.Float()
+	pi.UnrealizedPNL = msgDec.Float()
+	pi.RealizedPNL = msgDec.Float()
+	pi.Account = msgDec.String()
+	return &pi
+}
+
+func NewPortfolioItemFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.PortfolioValue) *PortfolioItem {
+	pi := PortfolioItem{}
+	if pb == nil {
+		pi.Contract = NewContract()
+		return &pi
+	}
+	pi.Contract = NewContractFromProtobufDecoder(msgDec, pb.Contract)
+	pi.Position = NewDecimalFromProtobufDecoder(msgDec, pb.Position)
+	pi.MarketPrice = msgDec.Float(pb.MarketPrice)
+	pi.MarketValue = msgDec.Float(pb.MarketValue)
+	pi.AverageCost = msgDec.Float(pb.AverageCost)
+	pi.UnrealizedPNL = msgDec.Float(pb.UnrealizedPNL)
+	pi.RealizedPNL = msgDec.Float(pb.RealizedPNL)
+	pi.Account = msgDec.String(pb.AccountName)
+	return &pi
+}
+
+func (pi *PortfolioItem) String() string {
+	return fmt.Sprintf(
+		"Account: %s, Contract: [%s], Position: %s, MarketPrice: %f, MarketValue: %f, AverageCost: %f, "+
+			"UnrealizedPNL: %f, RealizedPNL: %f",
+		pi.Account,
+		pi.Contract,
+		pi.Position.String(),
+		pi.MarketPrice,
+		pi.MarketValue,
+		pi.AverageCost,
+		pi.UnrealizedPNL,
+		pi.RealizedPNL,
+	)
+}
+
+func (pi *PortfolioItem) isAccountUpdate() {}
+
+func (aut *AccountUpdateTime) String() string {
+	return "Time: " + aut.Time
+}
+
+func (aut *AccountUpdateTime) isAccountUpdate() {}
+
+func (ade *AccountDownloadEnd) String() string {
+	return "Download end: " + ade.Account
+}
+
+func (ade *AccountDownloadEnd) isAccountUpdate() {}
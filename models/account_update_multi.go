@@ -0,0 +1,74 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+// AccountUpdateMulti is an update received for an account updates multi subscription. It can be an
+// *AccountMultiValue or an *AccountMultiEnd.
+type AccountUpdateMulti interface {
+	String() string
+
+	isAccountUpdateMulti()
+}
+
+// AccountMultiValue is a single account value of an account and model code.
+type AccountMultiValue struct {
+	Account   string
+	ModelCode string
+	Key       string
+	Value     string
+	Currency  string
+}
+
+// AccountMultiEnd signals the initial download of the account values is complete.
+type AccountMultiEnd struct {
+}
+
+// -----------------------------------------------------------------------------
+
+// NewAccountMultiValueFromMessageDecoder decodes an account value received for a multi request. The request ID must
+// be already consumed from the decoder.
+func NewAccountMultiValueFromMessageDecoder(msgDec *message.Decoder) *AccountMultiValue {
+	av := AccountMultiValue{}
+	av.Account = msgDec.String()
+	av.ModelCode = msgDec.String()
+	av.Key = msgDec.String()
+	av.Value = msgDec.String()
+	av.Currency = msgDec.String()
+	return &av
+}
+
+func NewAccountMultiValueFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.AccountUpdateMulti) *AccountMultiValue {
+	av := AccountMultiValue{}
+	if pb == nil {
+		return &av
+	}
+	av.Account = msgDec.String(pb.Account)
+	av.ModelCode = msgDec.String(pb.ModelCode)
+	av.Key = msgDec.String(pb.Key)
+	av.Value = msgDec.String(pb.Value)
+	av.Currency = msgDec.String(pb.Currency)
+	return &av
+}
+
+func (av *AccountMultiValue) String() string {
+	return fmt.Sprintf(
+		"Account: %s, ModelCode: %s, Key: %s, Value: %s, Currency: %s",
+		av.Account, av.ModelCode, av.Key, av.Value, av.Currency,
+	)
+}
+
+func (av *AccountMultiValue) isAccountUpdateMulti() {}
+
+func (ame *AccountMultiEnd) String() string {
+	return "Account multi end"
+}
+
+func (ame *AccountMultiEnd) isAccountUpdateMulti() {}
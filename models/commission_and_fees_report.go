@@ -3,6 +3,8 @@ package models
 import (
 	"fmt"
 	"time"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
 )
 
 // -----------------------------------------------------------------------------
@@ -22,6 +24,24 @@ func NewCommissionAndFeesReport() *CommissionAndFeesReport {
 	return &CommissionAndFeesReport{}
 }
 
+func NewCommissionAndFeesReportFromMessageDecoder(msgDec *message.Decoder) *CommissionAndFeesReport {
+	cr := NewCommissionAndFeesReport()
+	msgDec.Skip() // version
+	cr.ExecID = msgDec.String()
+	cr.CommissionAndFees = msgDec.Float()
+	cr.Currency = msgDec.String()
+	cr.RealizedPNL = msgDec.Float()
+	cr.Yield = msgDec.Float()
+	yieldRedemptionDate := int(msgDec.Int32())
+	if yieldRedemptionDate > 0 {
+		cr.YieldRedemptionDate = time.Date(
+			yieldRedemptionDate/10000, time.Month((yieldRedemptionDate/100)%100), yieldRedemptionDate%100,
+			0, 0, 0, 0, time.UTC,
+		)
+	}
+	return cr
+}
+
 func (cr *CommissionAndFeesReport) String() string {
 	return fmt.Sprintf(
 		"ExecId: %s, CommissionAndFees: %f, Currency: %s, RealizedPnL: %f, Yield: %f, YieldRedemptionDate: %s",
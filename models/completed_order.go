@@ -0,0 +1,121 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+type CompletedOrder struct {
+	Contract   *Contract
+	Order      *Order
+	OrderState *OrderState
+}
+
+// -----------------------------------------------------------------------------
+
+func NewCompletedOrder() *CompletedOrder {
+	return &CompletedOrder{
+		Contract:   NewContract(),
+		Order:      NewOrder(),
+		OrderState: NewOrderState(),
+	}
+}
+
+func NewCompletedOrderFromMessageDecoder(msgDec *message.Decoder) *CompletedOrder {
+	co := NewCompletedOrder()
+
+	d := OrderDecoder{
+		order:      co.Order,
+		contract:   co.Contract,
+		orderState: co.OrderState,
+	}
+
+	d.decodeContractFields(msgDec)
+	d.decodeAction(msgDec)
+	d.decodeTotalQuantity(msgDec)
+	d.decodeOrderType(msgDec)
+	d.decodeLmtPrice(msgDec)
+	d.decodeAuxPrice(msgDec)
+	d.decodeTIF(msgDec)
+	d.decodeOcaGroup(msgDec)
+	d.decodeAccount(msgDec)
+	d.decodeOpenClose(msgDec)
+	d.decodeOrigin(msgDec)
+	d.decodeOrderRef(msgDec)
+	d.decodePermId(msgDec)
+	d.decodeOutsideRth(msgDec)
+	d.decodeHidden(msgDec)
+	d.decodeDiscretionaryAmount(msgDec)
+	d.decodeGoodAfterTime(msgDec)
+	d.decodeFAParams(msgDec)
+	d.decodeModelCode(msgDec)
+	d.decodeGoodTillDate(msgDec)
+	d.decodeRule80A(msgDec)
+	d.decodePercentOffset(msgDec)
+	d.decodeSettlingFirm(msgDec)
+	d.decodeShortSaleParams(msgDec)
+	d.decodeBoxOrderParams(msgDec)
+	d.decodePegToStkOrVolOrderParams(msgDec)
+	d.decodeDisplaySize(msgDec)
+	d.decodeSweepToFill(msgDec)
+	d.decodeAllOrNone(msgDec)
+	d.decodeMinQty(msgDec)
+	d.decodeOcaType(msgDec)
+	d.decodeTriggerMethod(msgDec)
+	d.decodeVolOrderParams(msgDec, false)
+	d.decodeTrailParams(msgDec)
+	d.decodeComboLegs(msgDec)
+	d.decodeSmartComboRoutingParams(msgDec)
+	d.decodeScaleOrderParams(msgDec)
+	d.decodeHedgeParams(msgDec)
+	d.decodeClearingParams(msgDec)
+	d.decodeNotHeld(msgDec)
+	d.decodeDeltaNeutral(msgDec)
+	d.decodeAlgoParams(msgDec)
+	d.decodeSolicited(msgDec)
+	d.decodeOrderStatus(msgDec)
+	d.decodeVolRandomizeFlags(msgDec)
+	d.decodePegBenchParams(msgDec)
+	d.decodeConditions(msgDec)
+	d.decodeStopPriceAndLmtPriceOffset(msgDec)
+	d.decodeCashQty(msgDec)
+	d.decodeDontUseAutoPriceForHedge(msgDec)
+	d.decodeIsOmsContainer(msgDec)
+	d.decodeAutoCancelDate(msgDec)
+	d.decodeFilledQuantity(msgDec)
+	d.decodeRefFuturesConId(msgDec)
+	d.decodeAutoCancelParent(msgDec)
+	d.decodeShareholder(msgDec)
+	d.decodeImbalanceOnly(msgDec)
+	d.decodeRouteMarketableToBbo(msgDec)
+	d.decodeParentPermId(msgDec)
+	d.decodeCompletedTime(msgDec)
+	d.decodeCompletedStatus(msgDec)
+	d.decodePegBestPegMidOrderAttributes(msgDec)
+	d.decodeCustomerAccount(msgDec)
+	d.decodeProfessionalCustomer(msgDec)
+	d.decodeSubmitter(msgDec)
+
+	// Done
+	return co
+}
+
+func NewCompletedOrderFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.CompletedOrder) *CompletedOrder {
+	if pb == nil {
+		return NewCompletedOrder()
+	}
+	return &CompletedOrder{
+		Contract:   NewContractFromProtobufDecoder(msgDec, pb.Contract),
+		Order:      NewOrderFromProtobufDecoder(msgDec, pb.Order),
+		OrderState: NewOrderStateFromProtobufDecoder(msgDec, pb.OrderState),
+	}
+}
+
+func (co *CompletedOrder) String() string {
+	return fmt.Sprintf("Contract: [%s], Order: [%s], OrderState: [%s]", co.Contract, co.Order, co.OrderState)
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/mxmauro/ibkr/proto/protobuf"
@@ -209,6 +210,26 @@ func (cd *ContractDetails) OnContractLastTradeDateOrContractMonthUpdated(isBond
 	}
 }
 
+// MarketRuleID returns the market rule that applies to the contract on the given exchange. MarketRuleIDs and
+// ValidExchanges are parallel lists.
+func (cd *ContractDetails) MarketRuleID(exchange string) (int32, bool) {
+	exchanges := strings.Split(cd.ValidExchanges, ",")
+	ruleIDs := strings.Split(cd.MarketRuleIDs, ",")
+	if len(exchanges) != len(ruleIDs) {
+		return 0, false
+	}
+	for idx, ex := range exchanges {
+		if strings.EqualFold(strings.TrimSpace(ex), exchange) {
+			ruleID, err := strconv.ParseInt(strings.TrimSpace(ruleIDs[idx]), 10, 32)
+			if err != nil {
+				return 0, false
+			}
+			return int32(ruleID), true
+		}
+	}
+	return 0, false
+}
+
 func (cd *ContractDetails) String() string {
 	return fmt.Sprintf(
 		"%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %t, %t, %f, %t, %s, %s, %s, %s, %t, %s, %s, %s, %s",
@@ -118,12 +118,24 @@ func (d *Decimal) Float() float64 {
 	return fixed.Fixed(*d).Float()
 }
 
+func (d *Decimal) IsZero() bool {
+	return fixed.Fixed(*d).IsZero()
+}
+
 func (d *Decimal) EncodeMessage(_ int) ([]byte, error) {
 	msgEnc := message.NewRawEncoder()
 	msgEnc.String(d.String())
 	return msgEnc.Bytes(), msgEnc.Err()
 }
 
+func decimalMaxProto(d *Decimal) *string {
+	if d == nil {
+		return nil
+	}
+	s := d.String()
+	return &s
+}
+
 func isMaxDecimal(s string) bool {
 	return s == "" || s == "2147483647" || s == "9223372036854775807" || s == "1.7976931348623157E308" || s == "-9223372036854775808"
 }
@@ -3,6 +3,7 @@ package models
 import (
 	"fmt"
 
+	"github.com/mxmauro/ibkr/utils/encoders/message"
 	"github.com/mxmauro/ibkr/utils/formatter"
 )
 
@@ -23,6 +24,21 @@ func NewDepthMktDataDescription() *DepthMktDataDescription {
 	return &dmdd
 }
 
+func NewDepthMktDataDescriptionFromMessageDecoder(msgDec *message.Decoder) *DepthMktDataDescription {
+	d := NewDepthMktDataDescription()
+	d.Exchange = msgDec.String()
+	d.SecType = NewSecurityTypeFromString(msgDec.String())
+	d.ListingExchange = msgDec.String()
+	d.ServiceDataType = msgDec.String()
+	d.AggGroup = msgDec.Int32Max()
+	return d
+}
+
+// SupportsL2 returns true if the exchange provides deep book (level 2) data.
+func (d *DepthMktDataDescription) SupportsL2() bool {
+	return d.ServiceDataType == "Deep" || d.ServiceDataType == "Deep2"
+}
+
 func (d *DepthMktDataDescription) String() string {
 	return fmt.Sprintf(
 		"Exchange: %s, SecType: %s, ListingExchange: %s, ServiceDataType: %s, AggGroup: %s",
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 	"github.com/mxmauro/ibkr/utils/formatter"
 )
 
@@ -43,6 +45,39 @@ func NewExecution() *Execution {
 	return &e
 }
 
+func NewExecutionFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.Execution) *Execution {
+	e := NewExecution()
+	if pb == nil {
+		return e
+	}
+	e.OrderID = msgDec.Int32(pb.OrderId)
+	e.ExecID = msgDec.String(pb.ExecId)
+	e.Time = msgDec.String(pb.Time)
+	e.AcctNumber = msgDec.String(pb.AcctNumber)
+	e.Exchange = msgDec.String(pb.Exchange)
+	e.Side = msgDec.String(pb.Side)
+	e.Shares = NewDecimalMaxFromProtobufDecoder(msgDec, pb.Shares)
+	e.Price = msgDec.Float(pb.Price)
+	e.PermID = msgDec.Int64(pb.PermId)
+	e.ClientID = msgDec.Int32(pb.ClientId)
+	if msgDec.Bool(pb.IsLiquidation) {
+		e.Liquidation = 1
+	}
+	e.CumQty = NewDecimalMaxFromProtobufDecoder(msgDec, pb.CumQty)
+	e.AvgPrice = msgDec.Float(pb.AvgPrice)
+	e.OrderRef = msgDec.String(pb.OrderRef)
+	e.EVRule = msgDec.String(pb.EvRule)
+	e.EVMultiplier = msgDec.Float(pb.EvMultiplier)
+	e.ModelCode = msgDec.String(pb.ModelCode)
+	e.LastLiquidity = Liquidities(msgDec.Int32(pb.LastLiquidity))
+	e.PendingPriceRevision = msgDec.Bool(pb.IsPriceRevisionPending)
+	e.Submitter = msgDec.String(pb.Submitter)
+	if pb.OptExerciseOrLapseType != nil {
+		e.OptExerciseOrLapse = OptionExercise(msgDec.Int32(pb.OptExerciseOrLapseType))
+	}
+	return e
+}
+
 func (e *Execution) String() string {
 	return fmt.Sprintf(
 		"ExecId: %s, Time: %s, Account: %s, Exchange: %s, Side: %s, Shares: %s, Price: %s, PermId: %s, ClientId: %s, OrderId: %s, Liquidation: %s, CumQty: %s, AvgPrice: %s, OrderRef: %s, EvRule: %s, EvMultiplier: %s, ModelCode: %s, LastLiquidity: %s,  PendingPriceRevision: %s, Submitter: %s, OptionExerciseType: %s",
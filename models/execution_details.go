@@ -0,0 +1,87 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+// ExecutionDetails pairs an execution with its contract and, once received, its commission and fees report.
+type ExecutionDetails struct {
+	Contract                *Contract
+	Execution               *Execution
+	CommissionAndFeesReport *CommissionAndFeesReport // Nil if the report was not received
+}
+
+// -----------------------------------------------------------------------------
+
+func NewExecutionDetails() *ExecutionDetails {
+	return &ExecutionDetails{
+		Contract:  NewContract(),
+		Execution: NewExecution(),
+	}
+}
+
+func NewExecutionDetailsFromMessageDecoder(msgDec *message.Decoder) *ExecutionDetails {
+	ed := NewExecutionDetails()
+
+	ed.Execution.OrderID = msgDec.Int32()
+
+	ed.Contract.ConID = msgDec.Int32()
+	ed.Contract.Symbol = msgDec.String()
+	ed.Contract.SecType = NewSecurityTypeFromString(msgDec.String())
+	ed.Contract.LastTradeDateOrContractMonth = msgDec.String()
+	ed.Contract.Strike = msgDec.FloatMax()
+	ed.Contract.Right = msgDec.String()
+	ed.Contract.Multiplier = msgDec.FloatMax()
+	ed.Contract.Exchange = msgDec.String()
+	ed.Contract.Currency = msgDec.String()
+	ed.Contract.LocalSymbol = msgDec.String()
+	ed.Contract.TradingClass = msgDec.String()
+
+	ed.Execution.ExecID = msgDec.String()
+	ed.Execution.Time = msgDec.String()
+	ed.Execution.AcctNumber = msgDec.String()
+	ed.Execution.Exchange = msgDec.String()
+	ed.Execution.Side = msgDec.String()
+	ed.Execution.Shares = NewDecimalMaxFromMessageDecoder(msgDec)
+	ed.Execution.Price = msgDec.Float()
+	ed.Execution.PermID = msgDec.Int64()
+	ed.Execution.ClientID = msgDec.Int32()
+	ed.Execution.Liquidation = msgDec.Int32()
+	ed.Execution.CumQty = NewDecimalMaxFromMessageDecoder(msgDec)
+	ed.Execution.AvgPrice = msgDec.Float()
+	ed.Execution.OrderRef = msgDec.String()
+	ed.Execution.EVRule = msgDec.String()
+	ed.Execution.EVMultiplier = msgDec.Float()
+	ed.Execution.ModelCode = msgDec.String()
+	ed.Execution.LastLiquidity = Liquidities(msgDec.Int32())
+	ed.Execution.PendingPriceRevision = msgDec.Bool()
+	ed.Execution.Submitter = msgDec.String()
+	ed.Execution.OptExerciseOrLapse = OptionExercise(msgDec.Int32())
+
+	// Done
+	return ed
+}
+
+func NewExecutionDetailsFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.ExecutionDetails) *ExecutionDetails {
+	if pb == nil {
+		return NewExecutionDetails()
+	}
+	return &ExecutionDetails{
+		Contract:  NewContractFromProtobufDecoder(msgDec, pb.Contract),
+		Execution: NewExecutionFromProtobufDecoder(msgDec, pb.Execution),
+	}
+}
+
+func (ed *ExecutionDetails) String() string {
+	s := fmt.Sprintf("Contract: [%s], Execution: [%s]", ed.Contract, ed.Execution)
+	if ed.CommissionAndFeesReport != nil {
+		s += fmt.Sprintf(", CommissionAndFeesReport: [%s]", ed.CommissionAndFeesReport)
+	}
+	return s
+}
@@ -2,6 +2,10 @@ package models
 
 import (
 	"time"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -24,3 +28,42 @@ func NewExecutionFilter() *ExecutionFilter {
 	ef := ExecutionFilter{}
 	return &ef
 }
+
+func (ef *ExecutionFilter) EncodeMessage(_ int) ([]byte, error) {
+	msgEnc := message.NewRawEncoder()
+	msgEnc.Int32(ef.ClientID)
+	msgEnc.String(ef.AcctCode)
+	msgEnc.String(ef.Time)
+	msgEnc.String(ef.Symbol)
+	msgEnc.String(string(ef.SecType))
+	msgEnc.String(ef.Exchange)
+	msgEnc.String(ef.Side)
+	msgEnc.Int32Max(ef.LastNDays)
+	msgEnc.Int(len(ef.SpecificDates))
+	for _, d := range ef.SpecificDates {
+		msgEnc.Int32(executionFilterDate(d))
+	}
+	return msgEnc.Bytes(), msgEnc.Err()
+}
+
+func (ef *ExecutionFilter) Proto() *protobuf.ExecutionFilter {
+	pb := protobuf.ExecutionFilter{
+		ClientId:  protofmt.Int32(ef.ClientID),
+		AcctCode:  protofmt.String(ef.AcctCode),
+		Time:      protofmt.String(ef.Time),
+		Symbol:    protofmt.String(ef.Symbol),
+		SecType:   protofmt.String(string(ef.SecType)),
+		Exchange:  protofmt.String(ef.Exchange),
+		Side:      protofmt.String(ef.Side),
+		LastNDays: protofmt.Int32Max(ef.LastNDays),
+	}
+	for _, d := range ef.SpecificDates {
+		pb.SpecificDates = append(pb.SpecificDates, executionFilterDate(d))
+	}
+	return &pb
+}
+
+// executionFilterDate converts the given date to the YYYYMMDD integer format expected by the server.
+func executionFilterDate(d time.Time) int32 {
+	return int32(d.Year()*10000 + int(d.Month())*100 + d.Day())
+}
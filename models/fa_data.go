@@ -1,5 +1,11 @@
 package models
 
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
 // -----------------------------------------------------------------------------
 
 type FaData int32
@@ -20,3 +26,173 @@ func (fa FaData) String() string {
 	}
 	return ""
 }
+
+// -----------------------------------------------------------------------------
+
+// FaConfig contains a Financial Advisor configuration. Depending on the FaData type, only Groups or Aliases is set.
+// XML holds the raw document sent by the server.
+type FaConfig struct {
+	XML     string
+	Groups  []FaGroup
+	Aliases []FaAccountAlias
+}
+
+// FaGroup is an allocation group. DefaultMethod is the allocation method applied to orders sent to the group,
+// i.e.: NetLiq, AvailableEquity or Equal.
+type FaGroup struct {
+	Name          string
+	DefaultMethod string
+	Accounts      []FaGroupAccount
+}
+
+// FaGroupAccount is an account belonging to an allocation group. Amount is only used by allocation methods that
+// require a per-account value.
+type FaGroupAccount struct {
+	Account string
+	Amount  *float64
+}
+
+// FaAccountAlias is a meaningful name given to an account.
+type FaAccountAlias struct {
+	Account string
+	Alias   string
+}
+
+type xmlFaListOfGroups struct {
+	XMLName xml.Name     `xml:"ListOfGroups"`
+	Groups  []xmlFaGroup `xml:"Group"`
+}
+
+type xmlFaGroup struct {
+	Name          string `xml:"name"`
+	DefaultMethod string `xml:"defaultMethod"`
+	ListOfAccts   struct {
+		VarName  string              `xml:"varName,attr"`
+		Accounts []xmlFaGroupAccount `xml:"Account"`
+	} `xml:"ListOfAccts"`
+}
+
+type xmlFaGroupAccount struct {
+	Account string   `xml:"acct"`
+	Amount  *float64 `xml:"amount,omitempty"`
+}
+
+type xmlFaListOfAccountAliases struct {
+	XMLName xml.Name            `xml:"ListOfAccountAliases"`
+	Aliases []xmlFaAccountAlias `xml:"AccountAlias"`
+}
+
+type xmlFaAccountAlias struct {
+	Account string `xml:"account"`
+	Alias   string `xml:"alias"`
+}
+
+// -----------------------------------------------------------------------------
+
+// NewFaConfigFromXML parses the XML document of the given Financial Advisor configuration type.
+func NewFaConfigFromXML(faData FaData, doc string) (*FaConfig, error) {
+	cfg := FaConfig{
+		XML: doc,
+	}
+
+	switch faData {
+	case FaDataGroups:
+		var list xmlFaListOfGroups
+
+		if len(strings.TrimSpace(doc)) > 0 {
+			err := xml.Unmarshal([]byte(doc), &list)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse fa groups [err=%w]", err)
+			}
+		}
+		cfg.Groups = make([]FaGroup, 0, len(list.Groups))
+		for _, g := range list.Groups {
+			group := FaGroup{
+				Name:          strings.TrimSpace(g.Name),
+				DefaultMethod: strings.TrimSpace(g.DefaultMethod),
+				Accounts:      make([]FaGroupAccount, 0, len(g.ListOfAccts.Accounts)),
+			}
+			for _, a := range g.ListOfAccts.Accounts {
+				group.Accounts = append(group.Accounts, FaGroupAccount{
+					Account: strings.TrimSpace(a.Account),
+					Amount:  a.Amount,
+				})
+			}
+			cfg.Groups = append(cfg.Groups, group)
+		}
+
+	case FaDataAliases:
+		var list xmlFaListOfAccountAliases
+
+		if len(strings.TrimSpace(doc)) > 0 {
+			err := xml.Unmarshal([]byte(doc), &list)
+			if err != nil {
+				return nil, fmt.Errorf("unable to parse fa aliases [err=%w]", err)
+			}
+		}
+		cfg.Aliases = make([]FaAccountAlias, 0, len(list.Aliases))
+		for _, a := range list.Aliases {
+			cfg.Aliases = append(cfg.Aliases, FaAccountAlias{
+				Account: strings.TrimSpace(a.Account),
+				Alias:   strings.TrimSpace(a.Alias),
+			})
+		}
+
+	default:
+		return nil, fmt.Errorf("unsupported fa data type: %d", faData)
+	}
+
+	// Done
+	return &cfg, nil
+}
+
+// EncodeXML builds the XML document of the given Financial Advisor configuration type from the typed fields.
+func (cfg *FaConfig) EncodeXML(faData FaData) (string, error) {
+	var v interface{}
+
+	switch faData {
+	case FaDataGroups:
+		list := xmlFaListOfGroups{
+			Groups: make([]xmlFaGroup, 0, len(cfg.Groups)),
+		}
+		for _, group := range cfg.Groups {
+			g := xmlFaGroup{
+				Name:          group.Name,
+				DefaultMethod: group.DefaultMethod,
+			}
+			g.ListOfAccts.VarName = "list"
+			g.ListOfAccts.Accounts = make([]xmlFaGroupAccount, 0, len(group.Accounts))
+			for _, account := range group.Accounts {
+				g.ListOfAccts.Accounts = append(g.ListOfAccts.Accounts, xmlFaGroupAccount{
+					Account: account.Account,
+					Amount:  account.Amount,
+				})
+			}
+			list.Groups = append(list.Groups, g)
+		}
+		v = list
+
+	case FaDataAliases:
+		list := xmlFaListOfAccountAliases{
+			Aliases: make([]xmlFaAccountAlias, 0, len(cfg.Aliases)),
+		}
+		for _, alias := range cfg.Aliases {
+			list.Aliases = append(list.Aliases, xmlFaAccountAlias{
+				Account: alias.Account,
+				Alias:   alias.Alias,
+			})
+		}
+		v = list
+
+	default:
+		return "", fmt.Errorf("unsupported fa data type: %d", faData)
+	}
+
+	b, err := xml.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	// Done
+	return xml.Header + string(b), nil
+}
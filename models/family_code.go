@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
 )
 
 // -----------------------------------------------------------------------------
@@ -17,6 +19,13 @@ func NewFamilyCode() FamilyCode {
 	return FamilyCode{}
 }
 
+func NewFamilyCodeFromMessageDecoder(msgDec *message.Decoder) FamilyCode {
+	f := NewFamilyCode()
+	f.AccountID = msgDec.String()
+	f.FamilyCodeStr = msgDec.String()
+	return f
+}
+
 func (f FamilyCode) String() string {
 	return fmt.Sprintf("AccountId: %s, FamilyCodeStr: %s", f.AccountID, f.FamilyCodeStr)
 }
@@ -0,0 +1,185 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+)
+
+// -----------------------------------------------------------------------------
+
+// FundamentalReportType is the type of fundamental data report to request.
+type FundamentalReportType string
+
+const (
+	FundamentalReportTypeSnapshot      FundamentalReportType = "ReportSnapshot"       // Company overview
+	FundamentalReportTypeFinSummary    FundamentalReportType = "ReportsFinSummary"    // Financial summary
+	FundamentalReportTypeRatios        FundamentalReportType = "ReportRatios"         // Financial ratios
+	FundamentalReportTypeFinStatements FundamentalReportType = "ReportsFinStatements" // Financial statements
+	FundamentalReportTypeEstimates     FundamentalReportType = "RESC"                 // Analyst estimates
+)
+
+// FundamentalData is a fundamental data report. XML always contains the document sent by the server. Snapshot and
+// FinancialSummary are only set for the corresponding report types.
+type FundamentalData struct {
+	ReportType       FundamentalReportType
+	XML              string
+	Snapshot         *FundamentalSnapshot
+	FinancialSummary *FundamentalFinancialSummary
+}
+
+// FundamentalSnapshot is the company overview contained in a ReportSnapshot report.
+type FundamentalSnapshot struct {
+	CompanyIDs  []FundamentalCodeValue `xml:"CoIDs>CoID"`
+	Issues      []FundamentalIssue     `xml:"Issues>Issue"`
+	GeneralInfo struct {
+		Status                 FundamentalCodeValue `xml:"CoStatus"`
+		Type                   FundamentalCodeValue `xml:"CoType"`
+		LastModified           string               `xml:"LastModified"`
+		LatestAvailableAnnual  string               `xml:"LatestAvailableAnnual"`
+		LatestAvailableInterim string               `xml:"LatestAvailableInterim"`
+		Employees              string               `xml:"Employees"`
+		SharesOut              struct {
+			Date       string `xml:"Date,attr"`
+			TotalFloat string `xml:"TotalFloat,attr"`
+			Value      string `xml:",chardata"`
+		} `xml:"SharesOut"`
+		ReportingCurrency FundamentalCodeValue `xml:"ReportingCurrency"`
+	} `xml:"CoGeneralInfo"`
+	Texts       []FundamentalText     `xml:"TextInfo>Text"`
+	Industries  []FundamentalIndustry `xml:"peerInfo>IndustryInfo>Industry"`
+	RatioGroups []struct {
+		ID     string             `xml:"ID,attr"`
+		Ratios []FundamentalRatio `xml:"Ratio"`
+	} `xml:"Ratios>Group"`
+	Forecasts []struct {
+		FieldName string `xml:"FieldName,attr"`
+		Type      string `xml:"Type,attr"`
+		Values    []struct {
+			PeriodType string `xml:"PeriodType,attr"`
+			Value      string `xml:",chardata"`
+		} `xml:"Value"`
+	} `xml:"ForecastData>Ratio"`
+}
+
+// FundamentalFinancialSummary is the content of a ReportsFinSummary report.
+type FundamentalFinancialSummary struct {
+	TotalRevenues     []FundamentalPeriodValue `xml:"TotalRevenues>TotalRevenue"`
+	DividendPerShares []FundamentalPeriodValue `xml:"DividendPerShares>DividendPerShare"`
+	EPSs              []FundamentalPeriodValue `xml:"EPSs>EPS"`
+	Dividends         []FundamentalDividend    `xml:"Dividends>Dividend"`
+}
+
+// FundamentalCodeValue is an element having a type or code attribute and a value.
+type FundamentalCodeValue struct {
+	Type  string `xml:"Type,attr"`
+	Code  string `xml:"Code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// FundamentalIssue is a security issued by the company.
+type FundamentalIssue struct {
+	ID       string                 `xml:"ID,attr"`
+	Type     string                 `xml:"Type,attr"`
+	Desc     string                 `xml:"Desc,attr"`
+	IssueIDs []FundamentalCodeValue `xml:"IssueID"`
+	Exchange FundamentalCodeValue   `xml:"Exchange"`
+}
+
+// FundamentalText is a text section of a report, i.e.: the business summary.
+type FundamentalText struct {
+	Type  string `xml:"Type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// FundamentalIndustry is an industry classification of the company.
+type FundamentalIndustry struct {
+	Type  string `xml:"type,attr"`
+	Order string `xml:"order,attr"`
+	Code  string `xml:"code,attr"`
+	Value string `xml:",chardata"`
+}
+
+// FundamentalRatio is a single ratio. Values are kept as strings because they can be numbers, dates or texts.
+type FundamentalRatio struct {
+	FieldName string `xml:"FieldName,attr"`
+	Type      string `xml:"Type,attr"`
+	Value     string `xml:",chardata"`
+}
+
+// FundamentalPeriodValue is a value reported for a period.
+type FundamentalPeriodValue struct {
+	AsOfDate   string  `xml:"asofDate,attr"`
+	ReportType string  `xml:"reportType,attr"`
+	Period     string  `xml:"period,attr"`
+	Value      float64 `xml:",chardata"`
+}
+
+// FundamentalDividend is a dividend paid or declared by the company.
+type FundamentalDividend struct {
+	Type            string  `xml:"type,attr"`
+	ExDate          string  `xml:"exDate,attr"`
+	RecordDate      string  `xml:"recordDate,attr"`
+	PayDate         string  `xml:"payDate,attr"`
+	DeclarationDate string  `xml:"declarationDate,attr"`
+	Value           float64 `xml:",chardata"`
+}
+
+// -----------------------------------------------------------------------------
+
+// NewFundamentalDataFromXML creates a fundamental data report and parses the XML of the supported report types. The
+// returned object always contains the raw XML, even if the parsing fails.
+func NewFundamentalDataFromXML(reportType FundamentalReportType, s string) (*FundamentalData, error) {
+	var err error
+
+	fd := FundamentalData{
+		ReportType: reportType,
+		XML:        s,
+	}
+	switch reportType {
+	case FundamentalReportTypeSnapshot:
+		snapshot := FundamentalSnapshot{}
+		err = xml.Unmarshal([]byte(s), &snapshot)
+		if err == nil {
+			fd.Snapshot = &snapshot
+		}
+
+	case FundamentalReportTypeFinSummary:
+		summary := FundamentalFinancialSummary{}
+		err = xml.Unmarshal([]byte(s), &summary)
+		if err == nil {
+			fd.FinancialSummary = &summary
+		}
+	}
+	if err != nil {
+		return &fd, fmt.Errorf("unable to parse fundamental data [err=%w]", err)
+	}
+
+	// Done
+	return &fd, nil
+}
+
+// CompanyName returns the name of the company or an empty string if not available.
+func (fs *FundamentalSnapshot) CompanyName() string {
+	for _, id := range fs.CompanyIDs {
+		if id.Type == "CompanyName" {
+			return id.Value
+		}
+	}
+	return ""
+}
+
+// Ratio returns the value of the ratio with the given field name, i.e.: PEEXCLXOR.
+func (fs *FundamentalSnapshot) Ratio(fieldName string) (string, bool) {
+	for _, group := range fs.RatioGroups {
+		for _, ratio := range group.Ratios {
+			if ratio.FieldName == fieldName {
+				return ratio.Value, true
+			}
+		}
+	}
+	return "", false
+}
+
+func (fd *FundamentalData) String() string {
+	return fmt.Sprintf("ReportType: %s, Length: %d", fd.ReportType, len(fd.XML))
+}
@@ -15,6 +15,7 @@ const (
 	GenericTickAuctionVolumePriceAndImbalance               GenericTick = 225
 	GenericTickMarkPrice                                    GenericTick = 232
 	GenericTickShortableAndShortableShares                  GenericTick = 236
+	GenericTickNews                                         GenericTick = 292
 	GenericTickTradeCount                                   GenericTick = 293
 	GenericTickTradeRate                                    GenericTick = 294
 	GenericTickVolumeRate                                   GenericTick = 295
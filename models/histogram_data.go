@@ -2,6 +2,10 @@ package models
 
 import (
 	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +22,23 @@ func NewHistogramData() HistogramData {
 	return hd
 }
 
+func NewHistogramDataFromMessageDecoder(msgDec *message.Decoder) HistogramData {
+	hd := NewHistogramData()
+	hd.Price = msgDec.Float()
+	hd.Size = NewDecimalMaxFromMessageDecoder(msgDec)
+	return hd
+}
+
+func NewHistogramDataFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.HistogramDataEntry) HistogramData {
+	hd := NewHistogramData()
+	if pb == nil {
+		return hd
+	}
+	hd.Price = msgDec.Float(pb.Price)
+	hd.Size = NewDecimalMaxFromProtobufDecoder(msgDec, pb.Size)
+	return hd
+}
+
 func (hd HistogramData) String() string {
 	return fmt.Sprintf("Price: %v, Size: %v", hd.Price, hd.Size.String())
 }
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+)
+
+// -----------------------------------------------------------------------------
+
+// HistoricalDataUpdate is an update of a historical data subscription. If IsNewBar is false, the bar replaces the
+// last one received.
+type HistoricalDataUpdate struct {
+	Bar      HistoricalDataBar
+	IsNewBar bool
+}
+
+// -----------------------------------------------------------------------------
+
+// NewHistoricalDataBarFromUpdateMessageDecoder decodes the bar of a historical data update. The request ID must be
+// already consumed from the decoder.
+func NewHistoricalDataBarFromUpdateMessageDecoder(msgDec *message.Decoder) HistoricalDataBar {
+	bar := NewHistoricalDataBar()
+	bar.Count = msgDec.Int32()
+	// Epoch because the request of historical data has the date format equal to 2.
+	bar.Date = msgDec.EpochTimestamp(false)
+	bar.Open = msgDec.Float()
+	bar.Close = msgDec.Float()
+	bar.High = msgDec.Float()
+	bar.Low = msgDec.Float()
+	bar.Wap = NewDecimalMaxFromMessageDecoder(msgDec)
+	bar.Volume = NewDecimalMaxFromMessageDecoder(msgDec)
+	return bar
+}
+
+func (upd HistoricalDataUpdate) String() string {
+	return fmt.Sprintf("%s, NewBar: %t", upd.Bar.String(), upd.IsNewBar)
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+)
+
+// -----------------------------------------------------------------------------
+
+// MarketRule defines the valid price increments of a contract on an exchange. Each increment applies to the prices
+// equal or above its low edge.
+type MarketRule struct {
+	ID              int32
+	PriceIncrements []PriceIncrement
+}
+
+// -----------------------------------------------------------------------------
+
+func NewMarketRuleFromMessageDecoder(msgDec *message.Decoder) *MarketRule {
+	mr := MarketRule{}
+	mr.ID = msgDec.Int32()
+	incrementsCount := int(msgDec.Int32())
+	if incrementsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative price increments count: %d", incrementsCount))
+		return &mr
+	}
+	mr.PriceIncrements = make([]PriceIncrement, 0, incrementsCount)
+	for i := 0; i < incrementsCount; i++ {
+		pi := NewPriceIncrement()
+		pi.LowEdge = msgDec.Float()
+		pi.Increment = msgDec.Float()
+		mr.PriceIncrements = append(mr.PriceIncrements, pi)
+	}
+	return &mr
+}
+
+// Increment returns the price increment that applies at the given price level or zero if the rule has no increments.
+func (mr *MarketRule) Increment(price float64) float64 {
+	increment := 0.0
+	price = math.Abs(price)
+	for _, pi := range mr.PriceIncrements {
+		if pi.LowEdge > price {
+			break
+		}
+		increment = pi.Increment
+	}
+	if increment == 0 && len(mr.PriceIncrements) > 0 {
+		increment = mr.PriceIncrements[0].Increment
+	}
+	return increment
+}
+
+// RoundPrice rounds the price to the nearest valid tick at its price level.
+func (mr *MarketRule) RoundPrice(price float64) float64 {
+	increment := mr.Increment(price)
+	if increment <= 0 {
+		return price
+	}
+	rounded := math.Round(price/increment) * increment
+
+	// Remove the floating point noise using the precision of the increment
+	decimals := 0
+	s := strconv.FormatFloat(increment, 'f', -1, 64)
+	if idx := strings.IndexByte(s, '.'); idx >= 0 {
+		decimals = len(s) - idx - 1
+	}
+	rounded, _ = strconv.ParseFloat(strconv.FormatFloat(rounded, 'f', decimals, 64), 64)
+	return rounded
+}
+
+func (mr *MarketRule) String() string {
+	sb := strings.Builder{}
+	_, _ = sb.WriteString(fmt.Sprintf("ID: %d, PriceIncrements: [", mr.ID))
+	for idx, pi := range mr.PriceIncrements {
+		if idx > 0 {
+			_, _ = sb.WriteString(", ")
+		}
+		_, _ = sb.WriteString("{" + pi.String() + "}")
+	}
+	_, _ = sb.WriteString("]")
+	return sb.String()
+}
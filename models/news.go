@@ -0,0 +1,186 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/formatter"
+)
+
+// -----------------------------------------------------------------------------
+
+// NewsArticleType indicates how the text of a NewsArticle is encoded.
+type NewsArticleType int32
+
+const (
+	NewsArticleTypeText   NewsArticleType = 0 // Plain text or HTML
+	NewsArticleTypeBinary NewsArticleType = 1 // Base64 encoded PDF
+)
+
+// NewsBulletinType is the kind of news bulletin.
+type NewsBulletinType int32
+
+const (
+	NewsBulletinTypeRegular              NewsBulletinType = 1
+	NewsBulletinTypeExchangeUnavailable  NewsBulletinType = 2
+	NewsBulletinTypeExchangeAvailableNow NewsBulletinType = 3
+)
+
+// HistoricalNewsTimeLayout is the layout of the times sent and received in historical news requests. Times are in UTC.
+const HistoricalNewsTimeLayout = "2006-01-02 15:04:05.0"
+
+// NewsProvider is a news source the user is subscribed to.
+type NewsProvider struct {
+	Code string
+	Name string
+}
+
+// NewsArticle is the body of a news article. Text articles are returned as is, binary ones are base64 encoded PDFs.
+type NewsArticle struct {
+	Type NewsArticleType
+	Text string
+}
+
+// HistoricalNews is a headline received for a historical news request.
+type HistoricalNews struct {
+	Time         time.Time // Zero if RawTime cannot be parsed
+	RawTime      string
+	ProviderCode string
+	ArticleID    string
+	Headline     string
+}
+
+// NewsBulletin is an IB news bulletin.
+type NewsBulletin struct {
+	MsgID          int32
+	Type           NewsBulletinType
+	Message        string
+	OriginExchange string
+}
+
+// TopMarketDataNews is a headline received when GenericTickNews is requested.
+type TopMarketDataNews struct {
+	Time         time.Time
+	ProviderCode string
+	ArticleID    string
+	Headline     string
+	ExtraData    string
+}
+
+// -----------------------------------------------------------------------------
+
+func NewNewsProviderFromMessageDecoder(msgDec *message.Decoder) NewsProvider {
+	np := NewsProvider{}
+	np.Code = msgDec.String()
+	np.Name = msgDec.String()
+	return np
+}
+
+func (np NewsProvider) String() string {
+	return fmt.Sprintf("Code: %s, Name: %s", np.Code, np.Name)
+}
+
+func NewNewsArticleFromMessageDecoder(msgDec *message.Decoder) *NewsArticle {
+	na := NewsArticle{}
+	na.Type = NewsArticleType(msgDec.Int32())
+	na.Text = msgDec.String()
+	return &na
+}
+
+// PDF returns the decoded document of a binary article.
+func (na *NewsArticle) PDF() ([]byte, error) {
+	if na.Type != NewsArticleTypeBinary {
+		return nil, errors.New("not a binary article")
+	}
+	return base64.StdEncoding.DecodeString(na.Text)
+}
+
+func (na *NewsArticle) String() string {
+	if na.Type == NewsArticleTypeBinary {
+		return fmt.Sprintf("Type: Binary, Length: %d", len(na.Text))
+	}
+	return fmt.Sprintf("Type: Text, Text: %s", na.Text)
+}
+
+// NewHistoricalNewsFromMessageDecoder decodes a historical news headline. The request ID must be already consumed from
+// the decoder.
+func NewHistoricalNewsFromMessageDecoder(msgDec *message.Decoder) *HistoricalNews {
+	hn := HistoricalNews{}
+	hn.RawTime = msgDec.String()
+	hn.ProviderCode = msgDec.String()
+	hn.ArticleID = msgDec.String()
+	hn.Headline = msgDec.String()
+	if msgDec.Err() == nil {
+		// The fractional seconds are optional and of variable length when parsing
+		t, err := time.ParseInLocation("2006-01-02 15:04:05", hn.RawTime, time.UTC)
+		if err == nil {
+			hn.Time = t
+		}
+	}
+	return &hn
+}
+
+func (hn *HistoricalNews) String() string {
+	return fmt.Sprintf(
+		"Time: %s, ProviderCode: %s, ArticleID: %s, Headline: %s",
+		hn.RawTime,
+		hn.ProviderCode,
+		hn.ArticleID,
+		hn.Headline,
+	)
+}
+
+func NewNewsBulletinFromMessageDecoder(msgDec *message.Decoder) *NewsBulletin {
+	nb := NewsBulletin{}
+	msgDec.Skip() // version
+	nb.MsgID = msgDec.Int32()
+	nb.Type = NewsBulletinType(msgDec.Int32())
+	nb.Message = msgDec.String()
+	nb.OriginExchange = msgDec.String()
+	return &nb
+}
+
+func (nb *NewsBulletin) String() string {
+	return fmt.Sprintf(
+		"MsgID: %d, Type: %d, Message: %s, OriginExchange: %s",
+		nb.MsgID,
+		nb.Type,
+		nb.Message,
+		nb.OriginExchange,
+	)
+}
+
+// NewTopMarketDataNewsFromMessageDecoder decodes a news tick. The ticker ID must be already consumed from the decoder.
+func NewTopMarketDataNewsFromMessageDecoder(msgDec *message.Decoder) *TopMarketDataNews {
+	t := TopMarketDataNews{}
+	t.Time = msgDec.EpochTimestamp(true)
+	t.ProviderCode = msgDec.String()
+	t.ArticleID = msgDec.String()
+	t.Headline = msgDec.String()
+	t.ExtraData = msgDec.String()
+	return &t
+}
+
+func (t *TopMarketDataNews) TickType() TickType {
+	return TickTypeNews
+}
+
+func (t *TopMarketDataNews) String() string {
+	sb := strings.Builder{}
+	_, _ = sb.WriteString("Type=")
+	_, _ = sb.WriteString(TickTypeNews.String())
+	_, _ = sb.WriteString(", Time=")
+	_, _ = sb.WriteString(formatter.Int64String(t.Time.UnixMilli()))
+	_, _ = sb.WriteString(", ProviderCode=\"")
+	_, _ = sb.WriteString(t.ProviderCode)
+	_, _ = sb.WriteString("\", ArticleID=\"")
+	_, _ = sb.WriteString(t.ArticleID)
+	_, _ = sb.WriteString("\", Headline=\"")
+	_, _ = sb.WriteString(t.Headline)
+	_, _ = sb.WriteString("\"")
+	return sb.String()
+}
@@ -0,0 +1,146 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+type OpenOrder struct {
+	Contract   *Contract
+	Order      *Order
+	OrderState *OrderState
+}
+
+// -----------------------------------------------------------------------------
+
+func NewOpenOrder() *OpenOrder {
+	return &OpenOrder{
+		Contract:   NewContract(),
+		Order:      NewOrder(),
+		OrderState: NewOrderState(),
+	}
+}
+
+func NewOpenOrderFromMessageDecoder(msgDec *message.Decoder) *OpenOrder {
+	oo := NewOpenOrder()
+
+	d := OrderDecoder{
+		order:      oo.Order,
+		contract:   oo.Contract,
+		orderState: oo.OrderState,
+	}
+
+	d.decodeOrderId(msgDec)
+	d.decodeContractFields(msgDec)
+	d.decodeAction(msgDec)
+	d.decodeTotalQuantity(msgDec)
+	d.decodeOrderType(msgDec)
+	d.decodeLmtPrice(msgDec)
+	d.decodeAuxPrice(msgDec)
+	d.decodeTIF(msgDec)
+	d.decodeOcaGroup(msgDec)
+	d.decodeAccount(msgDec)
+	d.decodeOpenClose(msgDec)
+	d.decodeOrigin(msgDec)
+	d.decodeOrderRef(msgDec)
+	d.decodeClientId(msgDec)
+	d.decodePermId(msgDec)
+	d.decodeOutsideRth(msgDec)
+	d.decodeHidden(msgDec)
+	d.decodeDiscretionaryAmount(msgDec)
+	d.decodeGoodAfterTime(msgDec)
+	d.skipSharesAllocation(msgDec)
+	d.decodeFAParams(msgDec)
+	d.decodeModelCode(msgDec)
+	d.decodeGoodTillDate(msgDec)
+	d.decodeRule80A(msgDec)
+	d.decodePercentOffset(msgDec)
+	d.decodeSettlingFirm(msgDec)
+	d.decodeShortSaleParams(msgDec)
+	d.decodeAuctionStrategy(msgDec)
+	d.decodeBoxOrderParams(msgDec)
+	d.decodePegToStkOrVolOrderParams(msgDec)
+	d.decodeDisplaySize(msgDec)
+	d.decodeBlockOrder(msgDec)
+	d.decodeSweepToFill(msgDec)
+	d.decodeAllOrNone(msgDec)
+	d.decodeMinQty(msgDec)
+	d.decodeOcaType(msgDec)
+	d.skipETradeOnly(msgDec)
+	d.skipFirmQuoteOnly(msgDec)
+	d.skipNbboPriceCap(msgDec)
+	d.decodeParentId(msgDec)
+	d.decodeTriggerMethod(msgDec)
+	d.decodeVolOrderParams(msgDec, true)
+	d.decodeTrailParams(msgDec)
+	d.decodeBasisPoints(msgDec)
+	d.decodeComboLegs(msgDec)
+	d.decodeSmartComboRoutingParams(msgDec)
+	d.decodeScaleOrderParams(msgDec)
+	d.decodeHedgeParams(msgDec)
+	d.decodeOptOutSmartRouting(msgDec)
+	d.decodeClearingParams(msgDec)
+	d.decodeNotHeld(msgDec)
+	d.decodeDeltaNeutral(msgDec)
+	d.decodeAlgoParams(msgDec)
+	d.decodeSolicited(msgDec)
+	d.decodeWhatIfInfoAndCommissionAndFees(msgDec)
+	d.decodeVolRandomizeFlags(msgDec)
+	d.decodePegBenchParams(msgDec)
+	d.decodeConditions(msgDec)
+	d.decodeAdjustedOrderParams(msgDec)
+	d.decodeSoftDollarTier(msgDec)
+	d.decodeCashQty(msgDec)
+	d.decodeDontUseAutoPriceForHedge(msgDec)
+	d.decodeIsOmsContainer(msgDec)
+	d.decodeDiscretionaryUpToLimitPrice(msgDec)
+	d.decodeUsePriceMgmtAlgo(msgDec)
+	d.decodeDuration(msgDec)
+	d.decodePostToAts(msgDec)
+	d.decodeAutoCancelParent(msgDec)
+	d.decodePegBestPegMidOrderAttributes(msgDec)
+	d.decodeCustomerAccount(msgDec)
+	d.decodeProfessionalCustomer(msgDec)
+	d.decodeBondAccruedInterest(msgDec)
+	d.decodeIncludeOvernight(msgDec)
+	d.decodeCMETaggingFields(msgDec)
+	d.decodeSubmitter(msgDec)
+	d.decodeImbalanceOnly(msgDec)
+
+	// Done
+	return oo
+}
+
+func NewOpenOrderFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.OpenOrder) *OpenOrder {
+	if pb == nil {
+		return NewOpenOrder()
+	}
+	oo := &OpenOrder{
+		Contract:   NewContractFromProtobufDecoder(msgDec, pb.Contract),
+		Order:      NewOrderFromProtobufDecoder(msgDec, pb.Order),
+		OrderState: NewOrderStateFromProtobufDecoder(msgDec, pb.OrderState),
+	}
+	if pb.OrderId != nil {
+		oo.Order.OrderID = msgDec.Int32(pb.OrderId)
+	}
+	if pb.Contract != nil {
+		oo.Order.OrderComboLegs = make([]OrderComboLeg, 0, len(pb.Contract.ComboLegs))
+		for _, pbcl := range pb.Contract.ComboLegs {
+			oo.Order.OrderComboLegs = append(oo.Order.OrderComboLegs, OrderComboLeg{
+				Price: msgDec.FloatMax(pbcl.PerLegPrice),
+			})
+		}
+	}
+	return oo
+}
+
+func (oo *OpenOrder) String() string {
+	return fmt.Sprintf("Contract: [%s], Order: [%s], OrderState: [%s]", oo.Contract, oo.Order, oo.OrderState)
+}
+
+func (oo *OpenOrder) isOrderEvent() {}
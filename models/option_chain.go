@@ -0,0 +1,129 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+)
+
+// -----------------------------------------------------------------------------
+
+// OptionChainParams contains the expirations and strikes of the options of an underlying traded on an exchange.
+type OptionChainParams struct {
+	Exchange        string
+	UnderlyingConID int32
+	TradingClass    string
+	Multiplier      string
+	Expirations     []string  // Sorted, in YYYYMMDD format
+	Strikes         []float64 // Sorted
+}
+
+// OptionChainFilter restricts the contracts generated from an option chain. Zero values do not filter.
+type OptionChainFilter struct {
+	FirstExpiration string   // Inclusive, in YYYYMMDD format
+	LastExpiration  string   // Inclusive, in YYYYMMDD format
+	MinStrike       *float64 // Inclusive
+	MaxStrike       *float64 // Inclusive
+	Rights          []string // Defaults to both calls and puts
+}
+
+// -----------------------------------------------------------------------------
+
+// NewOptionChainParamsFromMessageDecoder decodes the option chain parameters of an exchange. The request ID must be
+// already consumed from the decoder.
+func NewOptionChainParamsFromMessageDecoder(msgDec *message.Decoder) *OptionChainParams {
+	ocp := OptionChainParams{}
+	ocp.Exchange = msgDec.String()
+	ocp.UnderlyingConID = msgDec.Int32()
+	ocp.TradingClass = msgDec.String()
+	ocp.Multiplier = msgDec.String()
+
+	expirationsCount := int(msgDec.Int32())
+	if expirationsCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative expirations count: %d", expirationsCount))
+		return &ocp
+	}
+	ocp.Expirations = make([]string, 0, expirationsCount)
+	for i := 0; i < expirationsCount; i++ {
+		ocp.Expirations = append(ocp.Expirations, msgDec.String())
+	}
+
+	strikesCount := int(msgDec.Int32())
+	if strikesCount < 0 {
+		msgDec.SetErr(fmt.Errorf("negative strikes count: %d", strikesCount))
+		return &ocp
+	}
+	ocp.Strikes = make([]float64, 0, strikesCount)
+	for i := 0; i < strikesCount; i++ {
+		ocp.Strikes = append(ocp.Strikes, msgDec.Float())
+	}
+
+	sort.Strings(ocp.Expirations)
+	sort.Float64s(ocp.Strikes)
+	return &ocp
+}
+
+// Contracts expands the chain into option contracts of the given underlying that match the filter. Options on futures
+// are generated if the underlying is a future.
+func (ocp *OptionChainParams) Contracts(underlying *Contract, filter OptionChainFilter) []*Contract {
+	secType := SecurityTypeOption
+	if underlying.SecType == SecurityTypeFuture {
+		secType = SecurityTypeFuturesOption
+	}
+	rights := filter.Rights
+	if len(rights) == 0 {
+		rights = []string{"C", "P"}
+	}
+	multiplier, err := strconv.ParseFloat(ocp.Multiplier, 64)
+	hasMultiplier := err == nil
+
+	contracts := make([]*Contract, 0)
+	for _, expiration := range ocp.Expirations {
+		if len(filter.FirstExpiration) > 0 && expiration < filter.FirstExpiration {
+			continue
+		}
+		if len(filter.LastExpiration) > 0 && expiration > filter.LastExpiration {
+			continue
+		}
+		for _, strike := range ocp.Strikes {
+			if filter.MinStrike != nil && strike < *filter.MinStrike {
+				continue
+			}
+			if filter.MaxStrike != nil && strike > *filter.MaxStrike {
+				continue
+			}
+			for _, right := range rights {
+				contract := NewContract()
+				contract.Symbol = underlying.Symbol
+				contract.SecType = secType
+				contract.LastTradeDateOrContractMonth = expiration
+				k := strike
+				contract.Strike = &k
+				contract.Right = right
+				if hasMultiplier {
+					m := multiplier
+					contract.Multiplier = &m
+				}
+				contract.Exchange = ocp.Exchange
+				contract.Currency = underlying.Currency
+				contract.TradingClass = ocp.TradingClass
+				contracts = append(contracts, contract)
+			}
+		}
+	}
+	return contracts
+}
+
+func (ocp *OptionChainParams) String() string {
+	return fmt.Sprintf(
+		"Exchange: %s, UnderlyingConID: %d, TradingClass: %s, Multiplier: %s, Expirations: %d, Strikes: %d",
+		ocp.Exchange,
+		ocp.UnderlyingConID,
+		ocp.TradingClass,
+		ocp.Multiplier,
+		len(ocp.Expirations),
+		len(ocp.Strikes),
+	)
+}
@@ -5,6 +5,8 @@ import (
 	"math"
 	"strings"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 	"github.com/mxmauro/ibkr/utils/formatter"
 )
 
@@ -219,6 +221,7 @@ var (
 // NewOrder creates a default Order.
 func NewOrder() *Order {
 	order := &Order{
+		Transmit:        true,
 		ExemptCode:      -1,
 		AuctionStrategy: AuctionStrategyUnset,
 	}
@@ -226,6 +229,163 @@ func NewOrder() *Order {
 	return order
 }
 
+func NewOrderFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.Order) *Order {
+	o := NewOrder()
+	if pb == nil {
+		return o
+	}
+	o.OrderID = msgDec.Int32(pb.OrderId)
+	o.ClientID = msgDec.Int32(pb.ClientId)
+	o.PermID = msgDec.Int64(pb.PermId)
+	o.ParentID = msgDec.Int32(pb.ParentId)
+	o.Action = msgDec.String(pb.Action)
+	o.TotalQuantity = NewDecimalMaxFromProtobufDecoder(msgDec, pb.TotalQuantity)
+	o.DisplaySize = msgDec.Int32(pb.DisplaySize)
+	o.OrderType = msgDec.String(pb.OrderType)
+	o.LmtPrice = msgDec.FloatMax(pb.LmtPrice)
+	o.AuxPrice = msgDec.FloatMax(pb.AuxPrice)
+	o.TIF = NewTimeInForceFromString(msgDec.String(pb.Tif))
+	o.Account = msgDec.String(pb.Account)
+	o.SettlingFirm = msgDec.String(pb.SettlingFirm)
+	o.ClearingAccount = msgDec.String(pb.ClearingAccount)
+	o.ClearingIntent = msgDec.String(pb.ClearingIntent)
+	o.AllOrNone = msgDec.Bool(pb.AllOrNone)
+	o.BlockOrder = msgDec.Bool(pb.BlockOrder)
+	o.Hidden = msgDec.Bool(pb.Hidden)
+	o.OutsideRTH = msgDec.Bool(pb.OutsideRth)
+	o.SweepToFill = msgDec.Bool(pb.SweepToFill)
+	o.PercentOffset = msgDec.FloatMax(pb.PercentOffset)
+	o.TrailingPercent = msgDec.FloatMax(pb.TrailingPercent)
+	o.TrailStopPrice = msgDec.FloatMax(pb.TrailStopPrice)
+	o.MinQty = msgDec.Int32Max(pb.MinQty)
+	o.GoodAfterTime = msgDec.String(pb.GoodAfterTime)
+	o.GoodTillDate = msgDec.String(pb.GoodTillDate)
+	o.OCAGroup = msgDec.String(pb.OcaGroup)
+	o.OrderRef = msgDec.String(pb.OrderRef)
+	o.Rule80A = NewRule80aFromString(msgDec.String(pb.Rule80A))
+	o.OCAType = Oca(msgDec.Int32(pb.OcaType))
+	o.TriggerMethod = TriggerMethod(msgDec.Int32(pb.TriggerMethod))
+	o.ActiveStartTime = msgDec.String(pb.ActiveStartTime)
+	o.ActiveStopTime = msgDec.String(pb.ActiveStopTime)
+	o.FAGroup = msgDec.String(pb.FaGroup)
+	o.FAMethod = msgDec.String(pb.FaMethod)
+	o.FAPercentage = msgDec.String(pb.FaPercentage)
+	o.Volatility = msgDec.FloatMax(pb.Volatility)
+	o.VolatilityType = Volatility(msgDec.Int32(pb.VolatilityType))
+	o.ContinuousUpdate = msgDec.Bool(pb.ContinuousUpdate)
+	o.ReferencePriceType = msgDec.Int32(pb.ReferencePriceType)
+	o.DeltaNeutralOrderType = msgDec.String(pb.DeltaNeutralOrderType)
+	o.DeltaNeutralAuxPrice = msgDec.FloatMax(pb.DeltaNeutralAuxPrice)
+	o.DeltaNeutralConID = msgDec.Int32(pb.DeltaNeutralConId)
+	o.DeltaNeutralOpenClose = msgDec.String(pb.DeltaNeutralOpenClose)
+	o.DeltaNeutralShortSale = msgDec.Bool(pb.DeltaNeutralShortSale)
+	o.DeltaNeutralShortSaleSlot = msgDec.Int32(pb.DeltaNeutralShortSaleSlot)
+	o.DeltaNeutralDesignatedLocation = msgDec.String(pb.DeltaNeutralDesignatedLocation)
+	o.ScaleInitLevelSize = msgDec.Int32Max(pb.ScaleInitLevelSize)
+	o.ScaleSubsLevelSize = msgDec.Int32Max(pb.ScaleSubsLevelSize)
+	o.ScalePriceIncrement = msgDec.FloatMax(pb.ScalePriceIncrement)
+	o.ScalePriceAdjustValue = msgDec.FloatMax(pb.ScalePriceAdjustValue)
+	o.ScalePriceAdjustInterval = msgDec.Int32Max(pb.ScalePriceAdjustInterval)
+	o.ScaleProfitOffset = msgDec.FloatMax(pb.ScaleProfitOffset)
+	o.ScaleAutoReset = msgDec.Bool(pb.ScaleAutoReset)
+	o.ScaleInitPosition = msgDec.Int32Max(pb.ScaleInitPosition)
+	o.ScaleInitFillQty = msgDec.Int32Max(pb.ScaleInitFillQty)
+	o.ScaleRandomPercent = msgDec.Bool(pb.ScaleRandomPercent)
+	o.ScaleTable = msgDec.String(pb.ScaleTable)
+	o.HedgeType = msgDec.String(pb.HedgeType)
+	o.HedgeParam = msgDec.String(pb.HedgeParam)
+	o.AlgoStrategy = msgDec.String(pb.AlgoStrategy)
+	o.AlgoParams = newTagValueListFromProtobuf(pb.AlgoParams)
+	o.AlgoID = msgDec.String(pb.AlgoId)
+	o.SmartComboRoutingParams = newTagValueListFromProtobuf(pb.SmartComboRoutingParams)
+	o.WhatIf = msgDec.Bool(pb.WhatIf)
+	o.Transmit = msgDec.Bool(pb.Transmit)
+	o.OverridePercentageConstraints = msgDec.Bool(pb.OverridePercentageConstraints)
+	o.OpenClose = msgDec.String(pb.OpenClose)
+	o.Origin = msgDec.Int32(pb.Origin)
+	o.ShortSaleSlot = msgDec.Int32(pb.ShortSaleSlot)
+	o.DesignatedLocation = msgDec.String(pb.DesignatedLocation)
+	if pb.ExemptCode != nil {
+		o.ExemptCode = msgDec.Int32(pb.ExemptCode)
+	}
+	o.DeltaNeutralSettlingFirm = msgDec.String(pb.DeltaNeutralSettlingFirm)
+	o.DeltaNeutralClearingAccount = msgDec.String(pb.DeltaNeutralClearingAccount)
+	o.DeltaNeutralClearingIntent = msgDec.String(pb.DeltaNeutralClearingIntent)
+	o.DiscretionaryAmt = msgDec.Float(pb.DiscretionaryAmt)
+	o.OptOutSmartRouting = msgDec.Bool(pb.OptOutSmartRouting)
+	o.StartingPrice = msgDec.FloatMax(pb.StartingPrice)
+	o.StockRefPrice = msgDec.FloatMax(pb.StockRefPrice)
+	o.Delta = msgDec.FloatMax(pb.Delta)
+	o.StockRangeLower = msgDec.FloatMax(pb.StockRangeLower)
+	o.StockRangeUpper = msgDec.FloatMax(pb.StockRangeUpper)
+	o.NotHeld = msgDec.Bool(pb.NotHeld)
+	o.OrderMiscOptions = newTagValueListFromProtobuf(pb.OrderMiscOptions)
+	o.Solicited = msgDec.Bool(pb.Solicited)
+	o.RandomizeSize = msgDec.Bool(pb.RandomizeSize)
+	o.RandomizePrice = msgDec.Bool(pb.RandomizePrice)
+	o.ReferenceContractID = msgDec.Int32(pb.ReferenceContractId)
+	o.PeggedChangeAmount = msgDec.Float(pb.PeggedChangeAmount)
+	o.IsPeggedChangeAmountDecrease = msgDec.Bool(pb.IsPeggedChangeAmountDecrease)
+	o.ReferenceChangeAmount = msgDec.Float(pb.ReferenceChangeAmount)
+	o.ReferenceExchangeID = msgDec.String(pb.ReferenceExchangeId)
+	o.AdjustedOrderType = msgDec.String(pb.AdjustedOrderType)
+	o.TriggerPrice = msgDec.FloatMax(pb.TriggerPrice)
+	o.AdjustedStopPrice = msgDec.FloatMax(pb.AdjustedStopPrice)
+	o.AdjustedStopLimitPrice = msgDec.FloatMax(pb.AdjustedStopLimitPrice)
+	o.AdjustedTrailingAmount = msgDec.FloatMax(pb.AdjustedTrailingAmount)
+	o.AdjustableTrailingUnit = msgDec.Int32(pb.AdjustableTrailingUnit)
+	o.LmtPriceOffset = msgDec.FloatMax(pb.LmtPriceOffset)
+	o.Conditions = make([]OrderCondition, 0, len(pb.Conditions))
+	for _, pbc := range pb.Conditions {
+		cond := NewOrderConditionFromProtobufDecoder(msgDec, pbc)
+		if cond != nil {
+			o.Conditions = append(o.Conditions, cond)
+		}
+	}
+	o.ConditionsCancelOrder = msgDec.Bool(pb.ConditionsCancelOrder)
+	o.ConditionsIgnoreRth = msgDec.Bool(pb.ConditionsIgnoreRth)
+	o.ModelCode = msgDec.String(pb.ModelCode)
+	o.ExtOperator = msgDec.String(pb.ExtOperator)
+	o.SoftDollarTier = NewSoftDollarTierFromProtobufDecoder(msgDec, pb.SoftDollarTier)
+	o.CashQty = msgDec.FloatMax(pb.CashQty)
+	o.Mifid2DecisionMaker = msgDec.String(pb.Mifid2DecisionMaker)
+	o.Mifid2DecisionAlgo = msgDec.String(pb.Mifid2DecisionAlgo)
+	o.Mifid2ExecutionTrader = msgDec.String(pb.Mifid2ExecutionTrader)
+	o.Mifid2ExecutionAlgo = msgDec.String(pb.Mifid2ExecutionAlgo)
+	o.DontUseAutoPriceForHedge = msgDec.Bool(pb.DontUseAutoPriceForHedge)
+	o.IsOmsContainer = msgDec.Bool(pb.IsOmsContainer)
+	o.DiscretionaryUpToLimitPrice = msgDec.Bool(pb.DiscretionaryUpToLimitPrice)
+	o.AutoCancelDate = msgDec.String(pb.AutoCancelDate)
+	o.FilledQuantity = NewDecimalMaxFromProtobufDecoder(msgDec, pb.FilledQuantity)
+	o.RefFuturesConID = msgDec.Int32(pb.RefFuturesConId)
+	o.AutoCancelParent = msgDec.Bool(pb.AutoCancelParent)
+	o.Shareholder = msgDec.String(pb.Shareholder)
+	o.ImbalanceOnly = msgDec.Bool(pb.ImbalanceOnly)
+	o.RouteMarketableToBbo = msgDec.Bool(pb.RouteMarketableToBbo)
+	o.ParentPermID = msgDec.Int64(pb.ParentPermId)
+	o.UsePriceMgmtAlgo = msgDec.Int32(pb.UsePriceMgmtAlgo) == 1
+	o.Duration = msgDec.Int32Max(pb.Duration)
+	o.PostToAts = msgDec.Int32Max(pb.PostToAts)
+	o.AdvancedErrorOverride = msgDec.String(pb.AdvancedErrorOverride)
+	o.ManualOrderTime = msgDec.String(pb.ManualOrderTime)
+	o.MinTradeQty = msgDec.Int32Max(pb.MinTradeQty)
+	o.MinCompeteSize = msgDec.Int32Max(pb.MinCompeteSize)
+	if pb.CompeteAgainstBestOffset != nil && math.IsInf(*pb.CompeteAgainstBestOffset, 1) {
+		o.CompeteAgainstBestOffset = &CompeteAgainstBestOffsetUpToMid
+	} else {
+		o.CompeteAgainstBestOffset = msgDec.FloatMax(pb.CompeteAgainstBestOffset)
+	}
+	o.MidOffsetAtWhole = msgDec.FloatMax(pb.MidOffsetAtWhole)
+	o.MidOffsetAtHalf = msgDec.FloatMax(pb.MidOffsetAtHalf)
+	o.CustomerAccount = msgDec.String(pb.CustomerAccount)
+	o.ProfessionalCustomer = msgDec.Bool(pb.ProfessionalCustomer)
+	o.BondAccruedInterest = msgDec.String(pb.BondAccruedInterest)
+	o.IncludeOvernight = msgDec.Bool(pb.IncludeOvernight)
+	o.ManualOrderIndicator = msgDec.Int32Max(pb.ManualOrderIndicator)
+	o.Submitter = msgDec.String(pb.Submitter)
+	return o
+}
+
 func (o *Order) HasSameID(other *Order) bool {
 	if o.PermID != 0 && other.PermID != 0 {
 		return o.PermID == other.PermID
@@ -233,6 +393,162 @@ func (o *Order) HasSameID(other *Order) bool {
 	return o.OrderID == other.OrderID && o.ClientID == other.ClientID
 }
 
+func (o *Order) Proto() *protobuf.Order {
+	pb := protobuf.Order{
+		ClientId:                       protofmt.Int32(o.ClientID),
+		OrderId:                        protofmt.Int32(o.OrderID),
+		PermId:                         protofmt.Int64(o.PermID),
+		ParentId:                       protofmt.Int32(o.ParentID),
+		Action:                         protofmt.String(o.Action),
+		TotalQuantity:                  decimalMaxProto(o.TotalQuantity),
+		DisplaySize:                    protofmt.Int32(o.DisplaySize),
+		OrderType:                      protofmt.String(o.OrderType),
+		LmtPrice:                       protofmt.FloatMax(o.LmtPrice),
+		AuxPrice:                       protofmt.FloatMax(o.AuxPrice),
+		Tif:                            protofmt.String(string(o.TIF)),
+		Account:                        protofmt.String(o.Account),
+		SettlingFirm:                   protofmt.String(o.SettlingFirm),
+		ClearingAccount:                protofmt.String(o.ClearingAccount),
+		ClearingIntent:                 protofmt.String(o.ClearingIntent),
+		AllOrNone:                      protofmt.Bool(o.AllOrNone),
+		BlockOrder:                     protofmt.Bool(o.BlockOrder),
+		Hidden:                         protofmt.Bool(o.Hidden),
+		OutsideRth:                     protofmt.Bool(o.OutsideRTH),
+		SweepToFill:                    protofmt.Bool(o.SweepToFill),
+		PercentOffset:                  protofmt.FloatMax(o.PercentOffset),
+		TrailingPercent:                protofmt.FloatMax(o.TrailingPercent),
+		TrailStopPrice:                 protofmt.FloatMax(o.TrailStopPrice),
+		MinQty:                         protofmt.Int32Max(o.MinQty),
+		GoodAfterTime:                  protofmt.String(o.GoodAfterTime),
+		GoodTillDate:                   protofmt.String(o.GoodTillDate),
+		OcaGroup:                       protofmt.String(o.OCAGroup),
+		OrderRef:                       protofmt.String(o.OrderRef),
+		Rule80A:                        protofmt.String(string(o.Rule80A)),
+		OcaType:                        protofmt.Int32(int32(o.OCAType)),
+		TriggerMethod:                  protofmt.Int32(int32(o.TriggerMethod)),
+		ActiveStartTime:                protofmt.String(o.ActiveStartTime),
+		ActiveStopTime:                 protofmt.String(o.ActiveStopTime),
+		FaGroup:                        protofmt.String(o.FAGroup),
+		FaMethod:                       protofmt.String(o.FAMethod),
+		FaPercentage:                   protofmt.String(o.FAPercentage),
+		Volatility:                     protofmt.FloatMax(o.Volatility),
+		ContinuousUpdate:               protofmt.Bool(o.ContinuousUpdate),
+		DeltaNeutralOrderType:          protofmt.String(o.DeltaNeutralOrderType),
+		DeltaNeutralAuxPrice:           protofmt.FloatMax(o.DeltaNeutralAuxPrice),
+		DeltaNeutralConId:              protofmt.Int32(o.DeltaNeutralConID),
+		DeltaNeutralOpenClose:          protofmt.String(o.DeltaNeutralOpenClose),
+		DeltaNeutralShortSale:          protofmt.Bool(o.DeltaNeutralShortSale),
+		DeltaNeutralShortSaleSlot:      protofmt.Int32(o.DeltaNeutralShortSaleSlot),
+		DeltaNeutralDesignatedLocation: protofmt.String(o.DeltaNeutralDesignatedLocation),
+		ScaleInitLevelSize:             protofmt.Int32Max(o.ScaleInitLevelSize),
+		ScaleSubsLevelSize:             protofmt.Int32Max(o.ScaleSubsLevelSize),
+		ScalePriceIncrement:            protofmt.FloatMax(o.ScalePriceIncrement),
+		ScalePriceAdjustValue:          protofmt.FloatMax(o.ScalePriceAdjustValue),
+		ScalePriceAdjustInterval:       protofmt.Int32Max(o.ScalePriceAdjustInterval),
+		ScaleProfitOffset:              protofmt.FloatMax(o.ScaleProfitOffset),
+		ScaleAutoReset:                 protofmt.Bool(o.ScaleAutoReset),
+		ScaleInitPosition:              protofmt.Int32Max(o.ScaleInitPosition),
+		ScaleInitFillQty:               protofmt.Int32Max(o.ScaleInitFillQty),
+		ScaleRandomPercent:             protofmt.Bool(o.ScaleRandomPercent),
+		ScaleTable:                     protofmt.String(o.ScaleTable),
+		HedgeType:                      protofmt.String(o.HedgeType),
+		HedgeParam:                     protofmt.String(o.HedgeParam),
+		AlgoStrategy:                   protofmt.String(o.AlgoStrategy),
+		AlgoParams:                     tagValueListProto(o.AlgoParams),
+		AlgoId:                         protofmt.String(o.AlgoID),
+		SmartComboRoutingParams:        tagValueListProto(o.SmartComboRoutingParams),
+		WhatIf:                         protofmt.Bool(o.WhatIf),
+		Transmit:                       protofmt.Bool(o.Transmit),
+		OverridePercentageConstraints:  protofmt.Bool(o.OverridePercentageConstraints),
+		OpenClose:                      protofmt.String(o.OpenClose),
+		Origin:                         protofmt.Int32(o.Origin),
+		ShortSaleSlot:                  protofmt.Int32(o.ShortSaleSlot),
+		DesignatedLocation:             protofmt.String(o.DesignatedLocation),
+		ExemptCode:                     protofmt.Int32(o.ExemptCode),
+		DeltaNeutralSettlingFirm:       protofmt.String(o.DeltaNeutralSettlingFirm),
+		DeltaNeutralClearingAccount:    protofmt.String(o.DeltaNeutralClearingAccount),
+		DeltaNeutralClearingIntent:     protofmt.String(o.DeltaNeutralClearingIntent),
+		DiscretionaryAmt:               protofmt.Float(o.DiscretionaryAmt),
+		OptOutSmartRouting:             protofmt.Bool(o.OptOutSmartRouting),
+		StartingPrice:                  protofmt.FloatMax(o.StartingPrice),
+		StockRefPrice:                  protofmt.FloatMax(o.StockRefPrice),
+		Delta:                          protofmt.FloatMax(o.Delta),
+		StockRangeLower:                protofmt.FloatMax(o.StockRangeLower),
+		StockRangeUpper:                protofmt.FloatMax(o.StockRangeUpper),
+		NotHeld:                        protofmt.Bool(o.NotHeld),
+		OrderMiscOptions:               tagValueListProto(o.OrderMiscOptions),
+		Solicited:                      protofmt.Bool(o.Solicited),
+		RandomizeSize:                  protofmt.Bool(o.RandomizeSize),
+		RandomizePrice:                 protofmt.Bool(o.RandomizePrice),
+		AdjustedOrderType:              protofmt.String(o.AdjustedOrderType),
+		TriggerPrice:                   protofmt.FloatMax(o.TriggerPrice),
+		AdjustedStopPrice:              protofmt.FloatMax(o.AdjustedStopPrice),
+		AdjustedStopLimitPrice:         protofmt.FloatMax(o.AdjustedStopLimitPrice),
+		AdjustedTrailingAmount:         protofmt.FloatMax(o.AdjustedTrailingAmount),
+		AdjustableTrailingUnit:         protofmt.Int32(o.AdjustableTrailingUnit),
+		LmtPriceOffset:                 protofmt.FloatMax(o.LmtPriceOffset),
+		ConditionsCancelOrder:          protofmt.Bool(o.ConditionsCancelOrder),
+		ConditionsIgnoreRth:            protofmt.Bool(o.ConditionsIgnoreRth),
+		ModelCode:                      protofmt.String(o.ModelCode),
+		ExtOperator:                    protofmt.String(o.ExtOperator),
+		SoftDollarTier:                 o.SoftDollarTier.Proto(),
+		CashQty:                        protofmt.FloatMax(o.CashQty),
+		Mifid2DecisionMaker:            protofmt.String(o.Mifid2DecisionMaker),
+		Mifid2DecisionAlgo:             protofmt.String(o.Mifid2DecisionAlgo),
+		Mifid2ExecutionTrader:          protofmt.String(o.Mifid2ExecutionTrader),
+		Mifid2ExecutionAlgo:            protofmt.String(o.Mifid2ExecutionAlgo),
+		DontUseAutoPriceForHedge:       protofmt.Bool(o.DontUseAutoPriceForHedge),
+		IsOmsContainer:                 protofmt.Bool(o.IsOmsContainer),
+		DiscretionaryUpToLimitPrice:    protofmt.Bool(o.DiscretionaryUpToLimitPrice),
+		AutoCancelDate:                 protofmt.String(o.AutoCancelDate),
+		FilledQuantity:                 decimalMaxProto(o.FilledQuantity),
+		RefFuturesConId:                protofmt.Int32(o.RefFuturesConID),
+		AutoCancelParent:               protofmt.Bool(o.AutoCancelParent),
+		Shareholder:                    protofmt.String(o.Shareholder),
+		ImbalanceOnly:                  protofmt.Bool(o.ImbalanceOnly),
+		RouteMarketableToBbo:           protofmt.Bool(o.RouteMarketableToBbo),
+		ParentPermId:                   protofmt.Int64(o.ParentPermID),
+		Duration:                       protofmt.Int32Max(o.Duration),
+		PostToAts:                      protofmt.Int32Max(o.PostToAts),
+		AdvancedErrorOverride:          protofmt.String(o.AdvancedErrorOverride),
+		ManualOrderTime:                protofmt.String(o.ManualOrderTime),
+		MinTradeQty:                    protofmt.Int32Max(o.MinTradeQty),
+		MinCompeteSize:                 protofmt.Int32Max(o.MinCompeteSize),
+		CompeteAgainstBestOffset:       protofmt.FloatMax(o.CompeteAgainstBestOffset),
+		MidOffsetAtWhole:               protofmt.FloatMax(o.MidOffsetAtWhole),
+		MidOffsetAtHalf:                protofmt.FloatMax(o.MidOffsetAtHalf),
+		CustomerAccount:                protofmt.String(o.CustomerAccount),
+		ProfessionalCustomer:           protofmt.Bool(o.ProfessionalCustomer),
+		BondAccruedInterest:            protofmt.String(o.BondAccruedInterest),
+		IncludeOvernight:               protofmt.Bool(o.IncludeOvernight),
+		ManualOrderIndicator:           protofmt.Int32Max(o.ManualOrderIndicator),
+		Submitter:                      protofmt.String(o.Submitter),
+	}
+	if o.VolatilityType != VolatilityNone {
+		pb.VolatilityType = protofmt.Int32(int32(o.VolatilityType))
+	}
+	if o.ReferencePriceType != 0 {
+		pb.ReferencePriceType = protofmt.Int32(o.ReferencePriceType)
+	}
+	if o.OrderType == "PEG BENCH" {
+		pb.ReferenceContractId = protofmt.Int32(o.ReferenceContractID)
+		pb.PeggedChangeAmount = protofmt.Float(o.PeggedChangeAmount)
+		pb.IsPeggedChangeAmountDecrease = protofmt.Bool(o.IsPeggedChangeAmountDecrease)
+		pb.ReferenceChangeAmount = protofmt.Float(o.ReferenceChangeAmount)
+		pb.ReferenceExchangeId = protofmt.String(o.ReferenceExchangeID)
+	}
+	for _, cond := range o.Conditions {
+		pb.Conditions = append(pb.Conditions, newOrderConditionProto(cond))
+	}
+	if o.UsePriceMgmtAlgo {
+		pb.UsePriceMgmtAlgo = protofmt.Int32(1)
+	}
+	if o.CompeteAgainstBestOffset != nil && math.IsInf(*o.CompeteAgainstBestOffset, 1) {
+		pb.CompeteAgainstBestOffset = &CompeteAgainstBestOffsetUpToMid
+	}
+	return &pb
+}
+
 func (o *Order) String() string {
 	sb := strings.Builder{}
 	_, _ = sb.WriteString(fmt.Sprintf("%s, %s, %s: %s %s %s@%s %s",
@@ -2,6 +2,9 @@ package models
 
 import (
 	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -23,6 +26,21 @@ func NewOrderAllocation() *OrderAllocation {
 	return &oa
 }
 
+func NewOrderAllocationFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.OrderAllocation) *OrderAllocation {
+	oa := NewOrderAllocation()
+	if pb == nil {
+		return oa
+	}
+	oa.Account = msgDec.String(pb.Account)
+	oa.Position = NewDecimalMaxFromProtobufDecoder(msgDec, pb.Position)
+	oa.PositionDesired = NewDecimalMaxFromProtobufDecoder(msgDec, pb.PositionDesired)
+	oa.PositionAfter = NewDecimalMaxFromProtobufDecoder(msgDec, pb.PositionAfter)
+	oa.DesiredAllocQty = NewDecimalMaxFromProtobufDecoder(msgDec, pb.DesiredAllocQty)
+	oa.AllowedAllocQty = NewDecimalMaxFromProtobufDecoder(msgDec, pb.AllowedAllocQty)
+	oa.IsMonetary = msgDec.Bool(pb.IsMonetary)
+	return oa
+}
+
 func (oa *OrderAllocation) String() string {
 	return fmt.Sprint(
 		"Account: ", oa.Account,
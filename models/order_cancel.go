@@ -3,6 +3,8 @@ package models
 import (
 	"fmt"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 	"github.com/mxmauro/ibkr/utils/formatter"
 )
 
@@ -21,6 +23,15 @@ func NewOrderCancel() OrderCancel {
 	return oc
 }
 
+func (o OrderCancel) Proto() *protobuf.OrderCancel {
+	pb := protobuf.OrderCancel{
+		ManualOrderCancelTime: protofmt.String(o.ManualOrderCancelTime),
+		ExtOperator:           protofmt.String(o.ExtOperator),
+		ManualOrderIndicator:  protofmt.Int32Max(o.ManualOrderIndicator),
+	}
+	return &pb
+}
+
 func (o OrderCancel) String() string {
 	return fmt.Sprintf(
 		"ManualOrderCancelTime: %s, ManualOrderIndicator: %s",
@@ -4,7 +4,9 @@ import (
 	"errors"
 	"strings"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -25,7 +27,9 @@ type OrderCondition interface {
 	String() string
 
 	decode(msgDec *message.Decoder)
+	decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition)
 	makeFields() []any
+	proto(pb *protobuf.OrderCondition)
 }
 
 type orderConditionBase struct {
@@ -115,6 +119,30 @@ func NewOrderConditionFromMessageDecoder(msgDec *message.Decoder) OrderCondition
 	return cond
 }
 
+func NewOrderConditionFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) OrderCondition {
+	if pb == nil {
+		return nil
+	}
+	cond, err := NewOrderCondition(OrderConditionType(msgDec.Int32(pb.Type)))
+	if err != nil {
+		msgDec.SetErr(err)
+		return nil
+	}
+	cond.decodeProtobuf(msgDec, pb)
+	if msgDec.Err() != nil {
+		return nil
+	}
+	return cond
+}
+
+func newOrderConditionProto(cond OrderCondition) *protobuf.OrderCondition {
+	pb := protobuf.OrderCondition{
+		Type: protofmt.Int32(int32(cond.Type())),
+	}
+	cond.proto(&pb)
+	return &pb
+}
+
 // -----------------------------------------------------------------------------
 
 func (oc *orderConditionBase) Type() OrderConditionType {
@@ -126,6 +154,10 @@ func (oc *orderConditionBase) decode(msgDec *message.Decoder) {
 	oc.IsConjunctionConnection = strings.Compare(connector, "a") == 0
 }
 
+func (oc *orderConditionBase) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	oc.IsConjunctionConnection = msgDec.Bool(pb.IsConjunctionConnection)
+}
+
 func (oc *orderConditionBase) makeFields() []any {
 	if oc.IsConjunctionConnection {
 		return []any{"a"}
@@ -133,6 +165,10 @@ func (oc *orderConditionBase) makeFields() []any {
 	return []any{"o"}
 }
 
+func (oc *orderConditionBase) proto(pb *protobuf.OrderCondition) {
+	pb.IsConjunctionConnection = protofmt.Bool(oc.IsConjunctionConnection)
+}
+
 func (oc *orderConditionBase) String() string {
 	if oc.IsConjunctionConnection {
 		return "<AND>"
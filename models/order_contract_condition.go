@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -20,6 +22,18 @@ func (cc *OrderContractCondition) decode(msgDec *message.Decoder) {
 	cc.Exchange = msgDec.String()
 }
 
+func (cc *OrderContractCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	cc.OrderOperatorCondition.decodeProtobuf(msgDec, pb)
+	cc.ConID = msgDec.Int32(pb.ConId)
+	cc.Exchange = msgDec.String(pb.Exchange)
+}
+
 func (cc *OrderContractCondition) makeFields() []any {
 	return append(cc.OrderOperatorCondition.makeFields(), cc.ConID, cc.Exchange)
 }
+
+func (cc *OrderContractCondition) proto(pb *protobuf.OrderCondition) {
+	cc.OrderOperatorCondition.proto(pb)
+	pb.ConId = protofmt.Int32(cc.ConID)
+	pb.Exchange = protofmt.String(cc.Exchange)
+}
@@ -112,7 +112,6 @@ func (d *OrderDecoder) decodeFAParams(msgDec *message.Decoder) {
 	d.order.FAGroup = msgDec.String()
 	d.order.FAMethod = msgDec.String()
 	d.order.FAPercentage = msgDec.String()
-	msgDec.Skip() // skip deprecated FAProfile
 }
 
 func (d *OrderDecoder) decodeModelCode(msgDec *message.Decoder) {
@@ -0,0 +1,362 @@
+package models
+
+import (
+	"errors"
+	"math"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+)
+
+// -----------------------------------------------------------------------------
+
+// OrderEncoder serializes an order and its contract using the text-based
+// PLACE_ORDER message layout.
+type OrderEncoder struct {
+	order    *Order
+	contract *Contract
+}
+
+// -----------------------------------------------------------------------------
+
+var errUnsupportedOrderConditionField = errors.New("unsupported order condition field")
+
+// -----------------------------------------------------------------------------
+
+func NewOrderEncoder(order *Order, contract *Contract) *OrderEncoder {
+	return &OrderEncoder{
+		order:    order,
+		contract: contract,
+	}
+}
+
+func (e *OrderEncoder) EncodeMessage(_ int) ([]byte, error) {
+	msgEnc := message.NewRawEncoder()
+	e.encodeContractFields(msgEnc)
+	e.encodeMainOrderFields(msgEnc)
+	e.encodeExtendedOrderFields(msgEnc)
+	if e.contract.SecType == SecurityTypePair {
+		e.encodeComboLegs(msgEnc)
+	}
+	e.encodeAllocationAndShortSaleParams(msgEnc)
+	e.encodeAuctionAndBoxParams(msgEnc)
+	e.encodeVolOrderParams(msgEnc)
+	e.encodeScaleOrderParams(msgEnc)
+	e.encodeHedgeAndRoutingParams(msgEnc)
+	e.encodeAlgoParams(msgEnc)
+	e.encodePegBenchParams(msgEnc)
+	err := e.encodeConditions(msgEnc)
+	if err != nil {
+		return nil, err
+	}
+	e.encodeAdjustedOrderParams(msgEnc)
+	e.encodeTrailingFields(msgEnc)
+	return msgEnc.Bytes(), msgEnc.Err()
+}
+
+func (e *OrderEncoder) encodeContractFields(msgEnc *message.Encoder) {
+	c := e.contract
+	msgEnc.Int32(c.ConID)
+	msgEnc.String(c.Symbol)
+	msgEnc.String(string(c.SecType))
+	msgEnc.String(c.LastTradeDateOrContractMonth)
+	msgEnc.FloatMax(c.Strike)
+	msgEnc.String(c.Right)
+	msgEnc.FloatMax(c.Multiplier)
+	msgEnc.String(c.Exchange)
+	msgEnc.String(c.PrimaryExchange)
+	msgEnc.String(c.Currency)
+	msgEnc.String(c.LocalSymbol)
+	msgEnc.String(c.TradingClass)
+	msgEnc.String(c.SecIDType)
+	msgEnc.String(c.SecID)
+}
+
+func (e *OrderEncoder) encodeMainOrderFields(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(o.Action)
+	if o.TotalQuantity != nil {
+		msgEnc.Marshal(o.TotalQuantity, 0)
+	} else {
+		msgEnc.String("")
+	}
+	msgEnc.String(o.OrderType)
+	msgEnc.FloatMax(o.LmtPrice)
+	msgEnc.FloatMax(o.AuxPrice)
+}
+
+func (e *OrderEncoder) encodeExtendedOrderFields(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(string(o.TIF))
+	msgEnc.String(o.OCAGroup)
+	msgEnc.String(o.Account)
+	msgEnc.String(o.OpenClose)
+	msgEnc.Int32(o.Origin)
+	msgEnc.String(o.OrderRef)
+	msgEnc.Bool(o.Transmit)
+	msgEnc.Int32(o.ParentID)
+	msgEnc.Bool(o.BlockOrder)
+	msgEnc.Bool(o.SweepToFill)
+	msgEnc.Int32(o.DisplaySize)
+	msgEnc.Int32(int32(o.TriggerMethod))
+	msgEnc.Bool(o.OutsideRTH)
+	msgEnc.Bool(o.Hidden)
+}
+
+func (e *OrderEncoder) encodeComboLegs(msgEnc *message.Encoder) {
+	o := e.order
+	c := e.contract
+
+	msgEnc.Int(len(c.ComboLegs))
+	for _, leg := range c.ComboLegs {
+		msgEnc.Int32(leg.ConID)
+		msgEnc.Int32(leg.Ratio)
+		msgEnc.String(leg.Action)
+		msgEnc.String(leg.Exchange)
+		msgEnc.Int32(leg.OpenClose)
+		msgEnc.Int32(leg.ShortSalesSlot)
+		msgEnc.String(leg.DesignatedLocation)
+		msgEnc.Int32(leg.ExemptCode)
+	}
+
+	msgEnc.Int(len(o.OrderComboLegs))
+	for _, leg := range o.OrderComboLegs {
+		msgEnc.FloatMax(leg.Price)
+	}
+
+	msgEnc.Int(len(o.SmartComboRoutingParams))
+	for _, tv := range o.SmartComboRoutingParams {
+		msgEnc.String(tv.Tag)
+		msgEnc.String(tv.Value)
+	}
+}
+
+func (e *OrderEncoder) encodeAllocationAndShortSaleParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String("") // Deprecated shares allocation field
+	msgEnc.Float(o.DiscretionaryAmt)
+	msgEnc.String(o.GoodAfterTime)
+	msgEnc.String(o.GoodTillDate)
+	msgEnc.String(o.FAGroup)
+	msgEnc.String(o.FAMethod)
+	msgEnc.String(o.FAPercentage)
+	msgEnc.String(o.ModelCode)
+	msgEnc.Int32(o.ShortSaleSlot)
+	msgEnc.String(o.DesignatedLocation)
+	msgEnc.Int32(o.ExemptCode)
+	msgEnc.Int32(int32(o.OCAType))
+	msgEnc.String(string(o.Rule80A))
+	msgEnc.String(o.SettlingFirm)
+	msgEnc.Bool(o.AllOrNone)
+	msgEnc.Int32Max(o.MinQty)
+	msgEnc.FloatMax(o.PercentOffset)
+	msgEnc.Bool(false) // Deprecated eTradeOnly field
+	msgEnc.Bool(false) // Deprecated firmQuoteOnly field
+	msgEnc.String("")  // Deprecated nbboPriceCap field
+}
+
+func (e *OrderEncoder) encodeAuctionAndBoxParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.Int32(int32(o.AuctionStrategy))
+	msgEnc.FloatMax(o.StartingPrice)
+	msgEnc.FloatMax(o.StockRefPrice)
+	msgEnc.FloatMax(o.Delta)
+	msgEnc.FloatMax(o.StockRangeLower)
+	msgEnc.FloatMax(o.StockRangeUpper)
+	msgEnc.Bool(o.OverridePercentageConstraints)
+}
+
+func (e *OrderEncoder) encodeVolOrderParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.FloatMax(o.Volatility)
+	if o.VolatilityType != VolatilityNone {
+		msgEnc.Int32(int32(o.VolatilityType))
+	} else {
+		msgEnc.String("")
+	}
+	msgEnc.String(o.DeltaNeutralOrderType)
+	msgEnc.FloatMax(o.DeltaNeutralAuxPrice)
+	if len(o.DeltaNeutralOrderType) > 0 {
+		msgEnc.Int32(o.DeltaNeutralConID)
+		msgEnc.String(o.DeltaNeutralSettlingFirm)
+		msgEnc.String(o.DeltaNeutralClearingAccount)
+		msgEnc.String(o.DeltaNeutralClearingIntent)
+		msgEnc.String(o.DeltaNeutralOpenClose)
+		msgEnc.Bool(o.DeltaNeutralShortSale)
+		msgEnc.Int32(o.DeltaNeutralShortSaleSlot)
+		msgEnc.String(o.DeltaNeutralDesignatedLocation)
+	}
+	msgEnc.Bool(o.ContinuousUpdate)
+	if o.ReferencePriceType != 0 {
+		msgEnc.Int32(o.ReferencePriceType)
+	} else {
+		msgEnc.String("")
+	}
+	msgEnc.FloatMax(o.TrailStopPrice)
+	msgEnc.FloatMax(o.TrailingPercent)
+}
+
+func (e *OrderEncoder) encodeScaleOrderParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.Int32Max(o.ScaleInitLevelSize)
+	msgEnc.Int32Max(o.ScaleSubsLevelSize)
+	msgEnc.FloatMax(o.ScalePriceIncrement)
+	if o.ScalePriceIncrement != nil && *o.ScalePriceIncrement > 0 {
+		msgEnc.FloatMax(o.ScalePriceAdjustValue)
+		msgEnc.Int32Max(o.ScalePriceAdjustInterval)
+		msgEnc.FloatMax(o.ScaleProfitOffset)
+		msgEnc.Bool(o.ScaleAutoReset)
+		msgEnc.Int32Max(o.ScaleInitPosition)
+		msgEnc.Int32Max(o.ScaleInitFillQty)
+		msgEnc.Bool(o.ScaleRandomPercent)
+	}
+	msgEnc.String(o.ScaleTable)
+	msgEnc.String(o.ActiveStartTime)
+	msgEnc.String(o.ActiveStopTime)
+}
+
+func (e *OrderEncoder) encodeHedgeAndRoutingParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(o.HedgeType)
+	if len(o.HedgeType) > 0 {
+		msgEnc.String(o.HedgeParam)
+	}
+	msgEnc.Bool(o.OptOutSmartRouting)
+	msgEnc.String(o.ClearingAccount)
+	msgEnc.String(o.ClearingIntent)
+	msgEnc.Bool(o.NotHeld)
+
+	if e.contract.DeltaNeutralContract != nil {
+		msgEnc.Bool(true)
+		msgEnc.Marshal(e.contract.DeltaNeutralContract, 0)
+	} else {
+		msgEnc.Bool(false)
+	}
+}
+
+func (e *OrderEncoder) encodeAlgoParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(o.AlgoStrategy)
+	if len(o.AlgoStrategy) > 0 {
+		msgEnc.Int(len(o.AlgoParams))
+		for _, tv := range o.AlgoParams {
+			msgEnc.String(tv.Tag)
+			msgEnc.String(tv.Value)
+		}
+	}
+	msgEnc.String(o.AlgoID)
+	msgEnc.Bool(o.WhatIf)
+
+	miscOptions := TagValueList(o.OrderMiscOptions)
+	msgEnc.Marshal(&miscOptions, 0)
+
+	msgEnc.Bool(o.Solicited)
+	msgEnc.Bool(o.RandomizeSize)
+	msgEnc.Bool(o.RandomizePrice)
+}
+
+func (e *OrderEncoder) encodePegBenchParams(msgEnc *message.Encoder) {
+	o := e.order
+	if o.OrderType != "PEG BENCH" {
+		return
+	}
+	msgEnc.Int32(o.ReferenceContractID)
+	msgEnc.Bool(o.IsPeggedChangeAmountDecrease)
+	msgEnc.Float(o.PeggedChangeAmount)
+	msgEnc.Float(o.ReferenceChangeAmount)
+	msgEnc.String(o.ReferenceExchangeID)
+}
+
+func (e *OrderEncoder) encodeConditions(msgEnc *message.Encoder) error {
+	o := e.order
+	msgEnc.Int(len(o.Conditions))
+	if len(o.Conditions) == 0 {
+		return nil
+	}
+	for _, cond := range o.Conditions {
+		msgEnc.Int32(int32(cond.Type()))
+		for _, field := range cond.makeFields() {
+			switch v := field.(type) {
+			case string:
+				msgEnc.String(v)
+			case bool:
+				msgEnc.Bool(v)
+			case int32:
+				msgEnc.Int32(v)
+			case float64:
+				msgEnc.Float(v)
+			case TriggerMethod:
+				msgEnc.Int32(int32(v))
+			case SecurityType:
+				msgEnc.String(string(v))
+			default:
+				return errUnsupportedOrderConditionField
+			}
+		}
+	}
+	msgEnc.Bool(o.ConditionsIgnoreRth)
+	msgEnc.Bool(o.ConditionsCancelOrder)
+	return nil
+}
+
+func (e *OrderEncoder) encodeAdjustedOrderParams(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(o.AdjustedOrderType)
+	msgEnc.FloatMax(o.TriggerPrice)
+	msgEnc.FloatMax(o.LmtPriceOffset)
+	msgEnc.FloatMax(o.AdjustedStopPrice)
+	msgEnc.FloatMax(o.AdjustedStopLimitPrice)
+	msgEnc.FloatMax(o.AdjustedTrailingAmount)
+	msgEnc.Int32(o.AdjustableTrailingUnit)
+}
+
+func (e *OrderEncoder) encodeTrailingFields(msgEnc *message.Encoder) {
+	o := e.order
+	msgEnc.String(o.ExtOperator)
+	msgEnc.String(o.SoftDollarTier.Name)
+	msgEnc.String(o.SoftDollarTier.Value)
+	msgEnc.FloatMax(o.CashQty)
+	msgEnc.String(o.Mifid2DecisionMaker)
+	msgEnc.String(o.Mifid2DecisionAlgo)
+	msgEnc.String(o.Mifid2ExecutionTrader)
+	msgEnc.String(o.Mifid2ExecutionAlgo)
+	msgEnc.Bool(o.DontUseAutoPriceForHedge)
+	msgEnc.Bool(o.IsOmsContainer)
+	msgEnc.Bool(o.DiscretionaryUpToLimitPrice)
+	if o.UsePriceMgmtAlgo {
+		msgEnc.String("1")
+	} else {
+		msgEnc.String("")
+	}
+	msgEnc.Int32Max(o.Duration)
+	msgEnc.Int32Max(o.PostToAts)
+	msgEnc.Bool(o.AutoCancelParent)
+	msgEnc.String(o.AdvancedErrorOverride)
+	msgEnc.String(o.ManualOrderTime)
+
+	sendMidOffsets := false
+	if e.contract.Exchange == "IBKRATS" {
+		msgEnc.Int32Max(o.MinTradeQty)
+	}
+	switch o.OrderType {
+	case "PEG BEST", "PEGBEST":
+		msgEnc.Int32Max(o.MinCompeteSize)
+		if o.CompeteAgainstBestOffset != nil && math.IsInf(*o.CompeteAgainstBestOffset, 1) {
+			msgEnc.String("Infinity")
+			sendMidOffsets = true
+		} else {
+			msgEnc.FloatMax(o.CompeteAgainstBestOffset)
+		}
+	case "PEG MID", "PEGMID":
+		sendMidOffsets = true
+	}
+	if sendMidOffsets {
+		msgEnc.FloatMax(o.MidOffsetAtWhole)
+		msgEnc.FloatMax(o.MidOffsetAtHalf)
+	}
+
+	msgEnc.String(o.CustomerAccount)
+	msgEnc.Bool(o.ProfessionalCustomer)
+	msgEnc.Bool(o.IncludeOvernight)
+	msgEnc.Int32Max(o.ManualOrderIndicator)
+	msgEnc.Bool(o.ImbalanceOnly)
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// -----------------------------------------------------------------------------
+
+// OrderEvent is an event received for a placed order. It can be an *OrderStatusUpdate, an *OpenOrder or an
+// *OrderError.
+type OrderEvent interface {
+	String() string
+
+	isOrderEvent()
+}
+
+// OrderError is a warning or error sent by the server for a specific order.
+type OrderError struct {
+	Timestamp               time.Time
+	Code                    int
+	Message                 string
+	AdvancedOrderRejectJson string
+}
+
+// -----------------------------------------------------------------------------
+
+func (e *OrderError) String() string {
+	s := fmt.Sprintf("Timestamp: %s, Code: %d, Message: %s",
+		e.Timestamp.Format("2006/01/02 15:04:05"),
+		e.Code,
+		e.Message,
+	)
+	if len(e.AdvancedOrderRejectJson) > 0 {
+		s += ", AdvancedOrderRejectJson: " + e.AdvancedOrderRejectJson
+	}
+	return s
+}
+
+func (e *OrderError) isOrderEvent() {}
@@ -3,7 +3,9 @@ package models
 import (
 	"fmt"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -24,10 +26,24 @@ func (ec *OrderExecutionCondition) decode(msgDec *message.Decoder) {
 	ec.Symbol = msgDec.String()
 }
 
+func (ec *OrderExecutionCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	ec.orderConditionBase.decodeProtobuf(msgDec, pb)
+	ec.SecType = NewSecurityTypeFromString(msgDec.String(pb.SecType))
+	ec.Exchange = msgDec.String(pb.Exchange)
+	ec.Symbol = msgDec.String(pb.Symbol)
+}
+
 func (ec *OrderExecutionCondition) makeFields() []any {
 	return append(ec.orderConditionBase.makeFields(), ec.SecType, ec.Exchange, ec.Symbol)
 }
 
+func (ec *OrderExecutionCondition) proto(pb *protobuf.OrderCondition) {
+	ec.orderConditionBase.proto(pb)
+	pb.SecType = protofmt.String(string(ec.SecType))
+	pb.Exchange = protofmt.String(ec.Exchange)
+	pb.Symbol = protofmt.String(ec.Symbol)
+}
+
 func (ec *OrderExecutionCondition) String() string {
 	return fmt.Sprintf("trade occurs for %v symbol on %v exchange for %v security type", ec.Symbol, ec.Exchange, ec.SecType)
 }
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +20,16 @@ func (mc *OrderMarginCondition) decode(msgDec *message.Decoder) {
 	mc.Percent = msgDec.Int32()
 }
 
+func (mc *OrderMarginCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	mc.OrderOperatorCondition.decodeProtobuf(msgDec, pb)
+	mc.Percent = msgDec.Int32(pb.Percent)
+}
+
 func (mc *OrderMarginCondition) makeFields() []any {
 	return append(mc.OrderOperatorCondition.makeFields(), mc.Percent)
 }
+
+func (mc *OrderMarginCondition) proto(pb *protobuf.OrderCondition) {
+	mc.OrderOperatorCondition.proto(pb)
+	pb.Percent = protofmt.Int32(mc.Percent)
+}
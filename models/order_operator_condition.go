@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +20,16 @@ func (oc *OrderOperatorCondition) decode(msgDec *message.Decoder) {
 	oc.IsMore = msgDec.Bool()
 }
 
+func (oc *OrderOperatorCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	oc.orderConditionBase.decodeProtobuf(msgDec, pb)
+	oc.IsMore = msgDec.Bool(pb.IsMore)
+}
+
 func (oc *OrderOperatorCondition) makeFields() []any {
 	return append(oc.orderConditionBase.makeFields(), oc.IsMore)
 }
+
+func (oc *OrderOperatorCondition) proto(pb *protobuf.OrderCondition) {
+	oc.orderConditionBase.proto(pb)
+	pb.IsMore = protofmt.Bool(oc.IsMore)
+}
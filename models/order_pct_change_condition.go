@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +20,16 @@ func (pcc *OrderPercentChangeCondition) decode(msgDec *message.Decoder) {
 	pcc.ChangePercent = msgDec.Float()
 }
 
+func (pcc *OrderPercentChangeCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	pcc.OrderContractCondition.decodeProtobuf(msgDec, pb)
+	pcc.ChangePercent = msgDec.Float(pb.ChangePercent)
+}
+
 func (pcc *OrderPercentChangeCondition) makeFields() []any {
 	return append(pcc.OrderContractCondition.makeFields(), pcc.ChangePercent)
 }
+
+func (pcc *OrderPercentChangeCondition) proto(pb *protobuf.OrderCondition) {
+	pcc.OrderContractCondition.proto(pb)
+	pb.ChangePercent = protofmt.Float(pcc.ChangePercent)
+}
@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -20,6 +22,18 @@ func (pc *OrderPriceCondition) decode(msgDec *message.Decoder) {
 	pc.TriggerMethod = TriggerMethod(msgDec.Int32())
 }
 
+func (pc *OrderPriceCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	pc.OrderContractCondition.decodeProtobuf(msgDec, pb)
+	pc.Price = msgDec.Float(pb.Price)
+	pc.TriggerMethod = TriggerMethod(msgDec.Int32(pb.TriggerMethod))
+}
+
 func (pc *OrderPriceCondition) makeFields() []any {
 	return append(pc.OrderContractCondition.makeFields(), pc.Price, pc.TriggerMethod)
 }
+
+func (pc *OrderPriceCondition) proto(pb *protobuf.OrderCondition) {
+	pc.OrderContractCondition.proto(pb)
+	pb.Price = protofmt.Float(pc.Price)
+	pb.TriggerMethod = protofmt.Int32(int32(pc.TriggerMethod))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 	"github.com/mxmauro/ibkr/utils/formatter"
 )
 
@@ -52,6 +54,47 @@ func NewOrderState() *OrderState {
 	return &os
 }
 
+func NewOrderStateFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.OrderState) *OrderState {
+	os := NewOrderState()
+	if pb == nil {
+		return os
+	}
+	os.Status = msgDec.String(pb.Status)
+	os.InitMarginBefore = formatter.FloatMaxString(msgDec.FloatMax(pb.InitMarginBefore))
+	os.MaintMarginBefore = formatter.FloatMaxString(msgDec.FloatMax(pb.MaintMarginBefore))
+	os.EquityWithLoanBefore = formatter.FloatMaxString(msgDec.FloatMax(pb.EquityWithLoanBefore))
+	os.InitMarginChange = formatter.FloatMaxString(msgDec.FloatMax(pb.InitMarginChange))
+	os.MaintMarginChange = formatter.FloatMaxString(msgDec.FloatMax(pb.MaintMarginChange))
+	os.EquityWithLoanChange = formatter.FloatMaxString(msgDec.FloatMax(pb.EquityWithLoanChange))
+	os.InitMarginAfter = formatter.FloatMaxString(msgDec.FloatMax(pb.InitMarginAfter))
+	os.MaintMarginAfter = formatter.FloatMaxString(msgDec.FloatMax(pb.MaintMarginAfter))
+	os.EquityWithLoanAfter = formatter.FloatMaxString(msgDec.FloatMax(pb.EquityWithLoanAfter))
+	os.CommissionAndFees = msgDec.FloatMax(pb.CommissionAndFees)
+	os.MinCommissionAndFees = msgDec.FloatMax(pb.MinCommissionAndFees)
+	os.MaxCommissionAndFees = msgDec.FloatMax(pb.MaxCommissionAndFees)
+	os.CommissionAndFeesCurrency = msgDec.String(pb.CommissionAndFeesCurrency)
+	os.MarginCurrency = msgDec.String(pb.MarginCurrency)
+	os.InitMarginBeforeOutsideRTH = msgDec.FloatMax(pb.InitMarginBeforeOutsideRTH)
+	os.MaintMarginBeforeOutsideRTH = msgDec.FloatMax(pb.MaintMarginBeforeOutsideRTH)
+	os.EquityWithLoanBeforeOutsideRTH = msgDec.FloatMax(pb.EquityWithLoanBeforeOutsideRTH)
+	os.InitMarginChangeOutsideRTH = msgDec.FloatMax(pb.InitMarginChangeOutsideRTH)
+	os.MaintMarginChangeOutsideRTH = msgDec.FloatMax(pb.MaintMarginChangeOutsideRTH)
+	os.EquityWithLoanChangeOutsideRTH = msgDec.FloatMax(pb.EquityWithLoanChangeOutsideRTH)
+	os.InitMarginAfterOutsideRTH = msgDec.FloatMax(pb.InitMarginAfterOutsideRTH)
+	os.MaintMarginAfterOutsideRTH = msgDec.FloatMax(pb.MaintMarginAfterOutsideRTH)
+	os.EquityWithLoanAfterOutsideRTH = msgDec.FloatMax(pb.EquityWithLoanAfterOutsideRTH)
+	os.SuggestedSize = NewDecimalMaxFromProtobufDecoder(msgDec, pb.SuggestedSize)
+	os.RejectReason = msgDec.String(pb.RejectReason)
+	os.OrderAllocations = make([]*OrderAllocation, 0, len(pb.OrderAllocations))
+	for _, pboa := range pb.OrderAllocations {
+		os.OrderAllocations = append(os.OrderAllocations, NewOrderAllocationFromProtobufDecoder(msgDec, pboa))
+	}
+	os.WarningText = msgDec.String(pb.WarningText)
+	os.CompletedTime = msgDec.String(pb.CompletedTime)
+	os.CompletedStatus = msgDec.String(pb.CompletedStatus)
+	return os
+}
+
 func (os *OrderState) String() string {
 	s := fmt.Sprint(
 		"Status: ", os.Status,
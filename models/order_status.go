@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+type OrderStatus string
+
+const (
+	OrderStatusUnknown       OrderStatus = "Unknown"
+	OrderStatusApiPending    OrderStatus = "ApiPending"
+	OrderStatusPendingSubmit OrderStatus = "PendingSubmit"
+	OrderStatusPendingCancel OrderStatus = "PendingCancel"
+	OrderStatusPreSubmitted  OrderStatus = "PreSubmitted"
+	OrderStatusSubmitted     OrderStatus = "Submitted"
+	OrderStatusApiCancelled  OrderStatus = "ApiCancelled"
+	OrderStatusCancelled     OrderStatus = "Cancelled"
+	OrderStatusFilled        OrderStatus = "Filled"
+	OrderStatusInactive      OrderStatus = "Inactive"
+)
+
+// OrderStatusUpdate contains the status of an order as reported by the ORDER_STATUS message.
+type OrderStatusUpdate struct {
+	OrderID       OrderID
+	Status        OrderStatus
+	Filled        Decimal
+	Remaining     Decimal
+	AvgFillPrice  float64
+	PermID        int64
+	ParentID      int32
+	LastFillPrice float64
+	ClientID      int32
+	WhyHeld       string
+	MktCapPrice   float64
+}
+
+// -----------------------------------------------------------------------------
+
+func NewOrderStatusFromString(s string) OrderStatus {
+	switch s {
+	case string(OrderStatusApiPending):
+		return OrderStatusApiPending
+	case string(OrderStatusPendingSubmit):
+		return OrderStatusPendingSubmit
+	case string(OrderStatusPendingCancel):
+		return OrderStatusPendingCancel
+	case string(OrderStatusPreSubmitted):
+		return OrderStatusPreSubmitted
+	case string(OrderStatusSubmitted):
+		return OrderStatusSubmitted
+	case string(OrderStatusApiCancelled):
+		return OrderStatusApiCancelled
+	case string(OrderStatusCancelled):
+		return OrderStatusCancelled
+	case string(OrderStatusFilled):
+		return OrderStatusFilled
+	case string(OrderStatusInactive):
+		return OrderStatusInactive
+	}
+	return OrderStatusUnknown
+}
+
+// IsFinal returns true if the order will not receive further status transitions.
+func (os OrderStatus) IsFinal() bool {
+	switch os {
+	case OrderStatusApiCancelled:
+		fallthrough
+	case OrderStatusCancelled:
+		fallthrough
+	case OrderStatusFilled:
+		fallthrough
+	case OrderStatusInactive:
+		return true
+	}
+	return false
+}
+
+func (os OrderStatus) String() string {
+	return string(os)
+}
+
+// -----------------------------------------------------------------------------
+
+func NewOrderStatusUpdate() *OrderStatusUpdate {
+	return &OrderStatusUpdate{}
+}
+
+func NewOrderStatusUpdateFromMessageDecoder(msgDec *message.Decoder) *OrderStatusUpdate {
+	osu := NewOrderStatusUpdate()
+	osu.OrderID = OrderID(msgDec.Int32())
+	osu.Status = NewOrderStatusFromString(msgDec.String())
+	osu.Filled = NewDecimalFromMessageDecoder(msgDec)
+	osu.Remaining = NewDecimalFromMessageDecoder(msgDec)
+	osu.AvgFillPrice = msgDec.Float()
+	osu.PermID = msgDec.Int64()
+	osu.ParentID = msgDec.Int32()
+	osu.LastFillPrice = msgDec.Float()
+	osu.ClientID = msgDec.Int32()
+	osu.WhyHeld = msgDec.String()
+	osu.MktCapPrice = msgDec.Float()
+	return osu
+}
+
+func NewOrderStatusUpdateFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.OrderStatus) *OrderStatusUpdate {
+	osu := NewOrderStatusUpdate()
+	if pb == nil {
+		return osu
+	}
+	osu.OrderID = OrderID(msgDec.Int32(pb.OrderId))
+	osu.Status = NewOrderStatusFromString(msgDec.String(pb.Status))
+	osu.Filled = NewDecimalFromProtobufDecoder(msgDec, pb.Filled)
+	osu.Remaining = NewDecimalFromProtobufDecoder(msgDec, pb.Remaining)
+	osu.AvgFillPrice = msgDec.Float(pb.AvgFillPrice)
+	osu.PermID = msgDec.Int64(pb.PermId)
+	osu.ParentID = msgDec.Int32(pb.ParentId)
+	osu.LastFillPrice = msgDec.Float(pb.LastFillPrice)
+	osu.ClientID = msgDec.Int32(pb.ClientId)
+	osu.WhyHeld = msgDec.String(pb.WhyHeld)
+	osu.MktCapPrice = msgDec.Float(pb.MktCapPrice)
+	return osu
+}
+
+func (osu *OrderStatusUpdate) String() string {
+	return fmt.Sprintf(
+		"OrderID: %d, Status: %s, Filled: %s, Remaining: %s, AvgFillPrice: %f, PermID: %d, ParentID: %d, "+
+			"LastFillPrice: %f, ClientID: %d, WhyHeld: %s, MktCapPrice: %f",
+		osu.OrderID,
+		osu.Status,
+		osu.Filled.String(),
+		osu.Remaining.String(),
+		osu.AvgFillPrice,
+		osu.PermID,
+		osu.ParentID,
+		osu.LastFillPrice,
+		osu.ClientID,
+		osu.WhyHeld,
+		osu.MktCapPrice,
+	)
+}
+
+func (osu *OrderStatusUpdate) isOrderEvent() {}
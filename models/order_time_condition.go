@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -19,6 +21,16 @@ func (tc *OrderTimeCondition) decode(msgDec *message.Decoder) {
 	tc.Time = msgDec.String()
 }
 
+func (tc *OrderTimeCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	tc.OrderOperatorCondition.decodeProtobuf(msgDec, pb)
+	tc.Time = msgDec.String(pb.Time)
+}
+
 func (tc *OrderTimeCondition) makeFields() []any {
 	return append(tc.OrderOperatorCondition.makeFields(), tc.Time)
 }
+
+func (tc *OrderTimeCondition) proto(pb *protobuf.OrderCondition) {
+	tc.OrderOperatorCondition.proto(pb)
+	pb.Time = protofmt.String(tc.Time)
+}
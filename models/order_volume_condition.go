@@ -1,7 +1,9 @@
 package models
 
 import (
+	"github.com/mxmauro/ibkr/proto/protobuf"
 	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +20,16 @@ func (vc *OrderVolumeCondition) decode(msgDec *message.Decoder) {
 	vc.Volume = msgDec.Int32()
 }
 
+func (vc *OrderVolumeCondition) decodeProtobuf(msgDec *protofmt.Decoder, pb *protobuf.OrderCondition) {
+	vc.OrderContractCondition.decodeProtobuf(msgDec, pb)
+	vc.Volume = msgDec.Int32(pb.Volume)
+}
+
 func (vc *OrderVolumeCondition) makeFields() []any {
 	return append(vc.OrderContractCondition.makeFields(), vc.Volume)
 }
+
+func (vc *OrderVolumeCondition) proto(pb *protobuf.OrderCondition) {
+	vc.OrderContractCondition.proto(pb)
+	pb.Volume = protofmt.Int32(vc.Volume)
+}
@@ -0,0 +1,66 @@
+package models
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/formatter"
+)
+
+// -----------------------------------------------------------------------------
+
+// PnL is a profit and loss update of an account or of a single position. Values not sent by the server are nil.
+// Position and Value are only set on single position updates.
+type PnL struct {
+	DailyPnL      *float64
+	UnrealizedPnL *float64
+	RealizedPnL   *float64
+	Position      *Decimal
+	Value         *float64
+}
+
+// -----------------------------------------------------------------------------
+
+// NewPnLFromMessageDecoder decodes an account PnL update. The request ID must be already consumed from the decoder.
+func NewPnLFromMessageDecoder(msgDec *message.Decoder) *PnL {
+	pnl := PnL{}
+	pnl.DailyPnL = decodePnLValue(msgDec)
+	pnl.UnrealizedPnL = decodePnLValue(msgDec)
+	pnl.RealizedPnL = decodePnLValue(msgDec)
+	return &pnl
+}
+
+// NewPnLSingleFromMessageDecoder decodes a single position PnL update. The request ID must be already consumed from
+// the decoder.
+func NewPnLSingleFromMessageDecoder(msgDec *message.Decoder) *PnL {
+	pnl := PnL{}
+	pnl.Position = NewDecimalMaxFromMessageDecoder(msgDec)
+	pnl.DailyPnL = decodePnLValue(msgDec)
+	pnl.UnrealizedPnL = decodePnLValue(msgDec)
+	pnl.RealizedPnL = decodePnLValue(msgDec)
+	pnl.Value = decodePnLValue(msgDec)
+	return &pnl
+}
+
+// decodePnLValue decodes a PnL value. The server sends math.MaxFloat64 for values that are not available.
+func decodePnLValue(msgDec *message.Decoder) *float64 {
+	value := msgDec.FloatMax()
+	if value != nil && *value == math.MaxFloat64 {
+		return nil
+	}
+	return value
+}
+
+func (pnl *PnL) String() string {
+	s := fmt.Sprintf(
+		"DailyPnL: %s, UnrealizedPnL: %s, RealizedPnL: %s",
+		formatter.FloatMaxString(pnl.DailyPnL),
+		formatter.FloatMaxString(pnl.UnrealizedPnL),
+		formatter.FloatMaxString(pnl.RealizedPnL),
+	)
+	if pnl.Position != nil || pnl.Value != nil {
+		s += fmt.Sprintf(", Position: %s, Value: %s", pnl.Position.StringMax(), formatter.FloatMaxString(pnl.Value))
+	}
+	return s
+}
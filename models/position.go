@@ -0,0 +1,120 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+// PositionUpdate is an update received for a positions subscription. It can be a *Position or a *PositionEnd.
+type PositionUpdate interface {
+	String() string
+
+	isPositionUpdate()
+}
+
+// Position is a position held in an account. ModelCode is only set on positions received for a multi request.
+type Position struct {
+	Account   string
+	ModelCode string
+	Contract  *Contract
+	Quantity  Decimal
+	AvgCost   float64
+}
+
+// PositionEnd signals the initial download of the positions is complete.
+type PositionEnd struct {
+}
+
+// -----------------------------------------------------------------------------
+
+func NewPositionFromMessageDecoder(msgDec *message.Decoder) *Position {
+	pos := Position{
+		Contract: NewContract(),
+	}
+	msgDec.Skip() // version
+	pos.Account = msgDec.String()
+	pos.decodeContractAndQuantity(msgDec)
+	return &pos
+}
+
+func NewPositionFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.Position) *Position {
+	pos := Position{}
+	if pb == nil {
+		pos.Contract = NewContract()
+		return &pos
+	}
+	pos.Account = msgDec.String(pb.Account)
+	pos.Contract = NewContractFromProtobufDecoder(msgDec, pb.Contract)
+	pos.Quantity = NewDecimalFromProtobufDecoder(msgDec, pb.Position)
+	pos.AvgCost = msgDec.Float(pb.AvgCost)
+	return &pos
+}
+
+// NewPositionMultiFromMessageDecoder decodes a position received for a multi request. The request ID must be already
+// consumed from the decoder.
+func NewPositionMultiFromMessageDecoder(msgDec *message.Decoder) *Position {
+	pos := Position{
+		Contract: NewContract(),
+	}
+	pos.Account = msgDec.String()
+	pos.decodeContractAndQuantity(msgDec)
+	pos.ModelCode = msgDec.String()
+	return &pos
+}
+
+func NewPositionMultiFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.PositionMulti) *Position {
+	pos := Position{}
+	if pb == nil {
+		pos.Contract = NewContract()
+		return &pos
+	}
+	pos.Account = msgDec.String(pb.Account)
+	pos.ModelCode = msgDec.String(pb.ModelCode)
+	pos.Contract = NewContractFromProtobufDecoder(msgDec, pb.Contract)
+	pos.Quantity = NewDecimalFromProtobufDecoder(msgDec, pb.Position)
+	pos.AvgCost = msgDec.Float(pb.AvgCost)
+	return &pos
+}
+
+func (pos *Position) decodeContractAndQuantity(msgDec *message.Decoder) {
+	pos.Contract.ConID = msgDec.Int32()
+	pos.Contract.Symbol = msgDec.String()
+	pos.Contract.SecType = NewSecurityTypeFromString(msgDec.String())
+	pos.Contract.LastTradeDateOrContractMonth = msgDec.String()
+	pos.Contract.Strike = msgDec.FloatMax()
+	pos.Contract.Right = msgDec.String()
+	pos.Contract.Multiplier = msgDec.FloatMax()
+	pos.Contract.Exchange = msgDec.String()
+	pos.Contract.Currency = msgDec.String()
+	pos.Contract.LocalSymbol = msgDec.String()
+	pos.Contract.TradingClass = msgDec.String()
+	pos.Quantity = NewDecimalFromMessageDecoder(msgDec)
+	pos.AvgCost = msgDec.Float()
+}
+
+func (pos *Position) String() string {
+	s := fmt.Sprintf(
+		"Account: %s, Contract: [%s], Quantity: %s, AvgCost: %f",
+		pos.Account,
+		pos.Contract,
+		pos.Quantity.String(),
+		pos.AvgCost,
+	)
+	if len(pos.ModelCode) > 0 {
+		s += ", ModelCode: " + pos.ModelCode
+	}
+	return s
+}
+
+func (pos *Position) isPositionUpdate() {}
+
+func (pe *PositionEnd) String() string {
+	return "Positions end"
+}
+
+func (pe *PositionEnd) isPositionUpdate() {}
@@ -3,10 +3,19 @@ package models
 import (
 	"fmt"
 	"time"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
 
+// RealTimeBarSize is the size of the bars sent by the server for real-time bars requests. No other size is supported.
+const RealTimeBarSize = 5 * time.Second
+
+// -----------------------------------------------------------------------------
+
 type RealTimeBar struct {
 	Time    time.Time
 	EndTime time.Time
@@ -26,6 +35,38 @@ func NewRealTimeBar() RealTimeBar {
 	return rtb
 }
 
+// NewRealTimeBarFromMessageDecoder decodes a real-time bar. The request ID must be already consumed from the decoder.
+func NewRealTimeBarFromMessageDecoder(msgDec *message.Decoder) RealTimeBar {
+	rtb := NewRealTimeBar()
+	rtb.Time = msgDec.EpochTimestamp(false)
+	rtb.EndTime = rtb.Time.Add(RealTimeBarSize)
+	rtb.Open = msgDec.Float()
+	rtb.High = msgDec.Float()
+	rtb.Low = msgDec.Float()
+	rtb.Close = msgDec.Float()
+	rtb.Volume = NewDecimalMaxFromMessageDecoder(msgDec)
+	rtb.Wap = NewDecimalMaxFromMessageDecoder(msgDec)
+	rtb.Count = msgDec.Int32()
+	return rtb
+}
+
+func NewRealTimeBarFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.RealTimeBarTick) RealTimeBar {
+	rtb := NewRealTimeBar()
+	if pb == nil {
+		return rtb
+	}
+	rtb.Time = msgDec.EpochTimestamp(pb.Time, false)
+	rtb.EndTime = rtb.Time.Add(RealTimeBarSize)
+	rtb.Open = msgDec.Float(pb.Open)
+	rtb.High = msgDec.Float(pb.High)
+	rtb.Low = msgDec.Float(pb.Low)
+	rtb.Close = msgDec.Float(pb.Close)
+	rtb.Volume = NewDecimalMaxFromProtobufDecoder(msgDec, pb.Volume)
+	rtb.Wap = NewDecimalMaxFromProtobufDecoder(msgDec, pb.WAP)
+	rtb.Count = msgDec.Int32(pb.Count)
+	return rtb
+}
+
 func (rb RealTimeBar) String() string {
 	return fmt.Sprintf(
 		"Time: %s, Open: %f, High: %f, Low: %f, Close: %f, Volume: %s, Wap: %s, Count: %d",
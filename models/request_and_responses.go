@@ -14,6 +14,10 @@ type ManagedAccountsResponse struct {
 	Accounts []string
 }
 
+// NextOrderIDResponse carries no data. The received ID seeds the order ID allocator, which is the source of truth.
+type NextOrderIDResponse struct {
+}
+
 type HistoricalDataRequestOptions struct {
 	Contract                *Contract
 	EndDate                 time.Time
@@ -28,6 +32,13 @@ type HistoricalDataResponse struct {
 	Bars []HistoricalDataBar
 }
 
+type HistoricalDataStreamResponse struct {
+	Bars    []HistoricalDataBar
+	Channel chan HistoricalDataUpdate
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
 type HistoricalTicksRequestOptions struct {
 	Contract                *Contract
 	StartDate               time.Time
@@ -67,14 +78,19 @@ type MarketDataTypeRequestOptions struct {
 type TopMarketDataRequestOptions struct {
 	Contract               *Contract
 	AdditionalGenericTicks []GenericTick
+	NewsProviders          []string // Restricts the headlines received with GenericTickNews to these provider codes
 	Snapshot               bool
 	RegulatorySnapshot     bool
 }
 
+// TopMarketDataResponse is the handle of a top market data subscription. Ticks are delivered through Channel.
+// RequestParams receives, at most once, the parameters the server applied to the request. Reading it is optional
+// because it is buffered, so it never holds back Channel. Both channels are closed when the subscription ends.
 type TopMarketDataResponse struct {
-	Channel chan TopMarketData
-	Cancel  CancelFunc
-	Err     ErrFunc
+	Channel       chan TopMarketData
+	RequestParams chan *TopMarketDataRequestParams
+	Cancel        CancelFunc
+	Err           ErrFunc
 }
 
 type MarketDepthDataRequestOptions struct {
@@ -91,12 +107,211 @@ type MarketDepthDataResponse struct {
 }
 
 type HeadTimestampRequestOptions struct {
+	Contract                *Contract
+	WhatToShow              WhatToShow
+	OnlyRegularTradingHours bool
 }
 
 type HeadTimestampResponse struct {
 	Timestamp time.Time
 }
 
+type HistogramDataResponse struct {
+	Data []HistogramData
+}
+
+type PlaceOrderRequestOptions struct {
+	Contract *Contract
+	Order    *Order
+}
+
+type PlaceOrderResponse struct {
+	OrderID OrderID
+	Channel chan OrderEvent
+	Modify  ModifyOrderFunc
+	Cancel  CancelOrderFunc
+	Detach  CancelFunc
+	Err     ErrFunc
+}
+
+type ExerciseOptionsResponse struct {
+	OrderID OrderID
+	Channel chan OrderEvent
+	Detach  CancelFunc
+	Err     ErrFunc
+}
+
+type ModifyOrderRequestOptions struct {
+	OrderID  OrderID
+	Contract *Contract
+	Order    *Order
+}
+
+type CancelOrderRequestOptions struct {
+	OrderID     OrderID
+	OrderCancel OrderCancel
+}
+
+type OpenOrdersResponse struct {
+	OpenOrders []*OpenOrder
+	AllClients bool // Set when the orders of all clients were requested
+}
+
+type AutoOpenOrdersResponse struct {
+	Channel chan OrderEvent
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type CompletedOrdersResponse struct {
+	CompletedOrders []*CompletedOrder
+}
+
+type ExecutionsResponse struct {
+	Executions []*ExecutionDetails
+}
+
+type FillsRequestOptions struct {
+	CommissionReportTimeout time.Duration // Defaults to 5 seconds
+}
+
+type FillsResponse struct {
+	Channel chan *ExecutionDetails
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type AccountUpdatesResponse struct {
+	Channel  chan AccountUpdate
+	Snapshot *AccountSnapshot
+	Cancel   CancelFunc
+	Err      ErrFunc
+}
+
+type AccountSummarySnapshotResponse struct {
+	Summary AccountSummary
+}
+
+type AccountSummaryResponse struct {
+	Channel chan AccountSummaryUpdate
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type AccountUpdatesMultiResponse struct {
+	Channel chan AccountUpdateMulti
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type PositionsSnapshotResponse struct {
+	Positions []Position
+}
+
+type PositionsResponse struct {
+	Channel chan PositionUpdate
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type PnLResponse struct {
+	Channel chan *PnL
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type RealTimeBarsResponse struct {
+	Channel chan RealTimeBar
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type TickByTickResponse struct {
+	Channel chan TickByTick
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type ScannerParametersResponse struct {
+	Parameters *ScannerParameters
+}
+
+type ScannerResponse struct {
+	Channel chan []*ScannerResult
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type NewsProvidersResponse struct {
+	Providers []NewsProvider
+}
+
+type NewsArticleResponse struct {
+	Article *NewsArticle
+}
+
+type HistoricalNewsResponse struct {
+	News    []*HistoricalNews
+	HasMore bool
+}
+
+type NewsBulletinsResponse struct {
+	Channel chan *NewsBulletin
+	Cancel  CancelFunc
+	Err     ErrFunc
+}
+
+type FundamentalDataResponse struct {
+	ReportType FundamentalReportType
+	Data       *FundamentalData
+	ParseErr   error
+}
+
+type OptionChainParamsResponse struct {
+	Params []*OptionChainParams
+}
+
+type OptionComputationResponse struct {
+	Computation *TopMarketDataOptionComputation
+}
+
+type MarketRuleResponse struct {
+	Rule *MarketRule
+}
+
+type SmartComponentsResponse struct {
+	Components []SmartComponent
+}
+
+type MarketDepthExchangesResponse struct {
+	Exchanges []*DepthMktDataDescription
+}
+
+type FamilyCodesResponse struct {
+	FamilyCodes []FamilyCode
+}
+
+type SoftDollarTiersResponse struct {
+	Tiers []SoftDollarTier
+}
+
+type UserInfoResponse struct {
+	WhiteBrandingID string
+}
+
+type FaResponse struct {
+	FaData FaData
+	XML    string
+}
+
+type ReplaceFaResponse struct {
+	Text string
+}
+
 type CancelFunc func()
 
 type ErrFunc func() error
+
+type ModifyOrderFunc func(order *Order) error
+
+type CancelOrderFunc func(orderCancel OrderCancel) error
@@ -0,0 +1,217 @@
+package models
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+
+// ScannerParameters contains the market scanner parameters available to the user. XML holds the raw document sent by
+// the server for values not covered by the typed fields.
+type ScannerParameters struct {
+	XML         string
+	Instruments []ScannerInstrument
+	Locations   []ScannerLocation
+	ScanTypes   []ScannerScanType
+	Filters     []ScannerFilter
+}
+
+// ScannerInstrument is an instrument type that can be scanned, i.e.: STK.
+type ScannerInstrument struct {
+	Name    string
+	Type    string
+	Filters []string
+}
+
+// ScannerLocation is a node of the location tree. Leaf locations have a LocationCode to use in a ScannerSubscription.
+type ScannerLocation struct {
+	DisplayName   string
+	LocationCode  string
+	Instruments   []string
+	RouteExchange string
+	Locations     []ScannerLocation
+}
+
+// ScannerScanType is a scan code that can be used in a ScannerSubscription, i.e.: TOP_PERC_GAIN.
+type ScannerScanType struct {
+	DisplayName string
+	ScanCode    string
+	Instruments []string
+}
+
+// ScannerFilter is a filter that can be applied to a scanner subscription using its field codes as tags.
+type ScannerFilter struct {
+	ID       string
+	Category string
+	IsRange  bool
+	Fields   []ScannerFilterField
+}
+
+// ScannerFilterField is a single field of a ScannerFilter.
+type ScannerFilterField struct {
+	Code        string
+	DisplayName string
+	Type        string
+}
+
+type xmlScanParameterResponse struct {
+	InstrumentLists []struct {
+		Instruments []struct {
+			Name    string `xml:"name"`
+			Type    string `xml:"type"`
+			Filters string `xml:"filters"`
+		} `xml:"Instrument"`
+	} `xml:"InstrumentList"`
+	LocationTrees []xmlScanLocationTree `xml:"LocationTree"`
+	ScanTypeLists []struct {
+		ScanTypes []struct {
+			DisplayName string `xml:"displayName"`
+			ScanCode    string `xml:"scanCode"`
+			Instruments string `xml:"instruments"`
+		} `xml:"ScanType"`
+	} `xml:"ScanTypeList"`
+	FilterLists []struct {
+		Filters []struct {
+			XMLName  xml.Name
+			ID       string `xml:"id"`
+			Category string `xml:"category"`
+			Fields   []struct {
+				Type        string `xml:"type,attr"`
+				Code        string `xml:"code"`
+				DisplayName string `xml:"displayName"`
+			} `xml:"AbstractField"`
+		} `xml:",any"`
+	} `xml:"FilterList"`
+}
+
+type xmlScanLocationTree struct {
+	Locations []struct {
+		DisplayName   string                `xml:"displayName"`
+		LocationCode  string                `xml:"locationCode"`
+		Instruments   string                `xml:"instruments"`
+		RouteExchange string                `xml:"routeExchange"`
+		LocationTrees []xmlScanLocationTree `xml:"LocationTree"`
+	} `xml:"Location"`
+}
+
+// -----------------------------------------------------------------------------
+
+// NewScannerParametersFromXML parses the scanner parameters document sent by the server. Entries repeated in more than
+// one list are only added once.
+func NewScannerParametersFromXML(s string) (*ScannerParameters, error) {
+	var doc xmlScanParameterResponse
+
+	err := xml.Unmarshal([]byte(s), &doc)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse scanner parameters [err=%w]", err)
+	}
+
+	sp := ScannerParameters{
+		XML:         s,
+		Instruments: make([]ScannerInstrument, 0),
+		Locations:   make([]ScannerLocation, 0),
+		ScanTypes:   make([]ScannerScanType, 0),
+		Filters:     make([]ScannerFilter, 0),
+	}
+
+	seen := make(map[string]struct{})
+	for _, list := range doc.InstrumentLists {
+		for _, inst := range list.Instruments {
+			if _, ok := seen[inst.Type]; ok {
+				continue
+			}
+			seen[inst.Type] = struct{}{}
+			sp.Instruments = append(sp.Instruments, ScannerInstrument{
+				Name:    inst.Name,
+				Type:    inst.Type,
+				Filters: splitScannerList(inst.Filters),
+			})
+		}
+	}
+
+	for _, tree := range doc.LocationTrees {
+		sp.Locations = append(sp.Locations, newScannerLocations(tree)...)
+	}
+
+	seen = make(map[string]struct{})
+	for _, list := range doc.ScanTypeLists {
+		for _, st := range list.ScanTypes {
+			if _, ok := seen[st.ScanCode]; ok {
+				continue
+			}
+			seen[st.ScanCode] = struct{}{}
+			sp.ScanTypes = append(sp.ScanTypes, ScannerScanType{
+				DisplayName: st.DisplayName,
+				ScanCode:    st.ScanCode,
+				Instruments: splitScannerList(st.Instruments),
+			})
+		}
+	}
+
+	seen = make(map[string]struct{})
+	for _, list := range doc.FilterLists {
+		for _, f := range list.Filters {
+			if _, ok := seen[f.ID]; ok {
+				continue
+			}
+			seen[f.ID] = struct{}{}
+			filter := ScannerFilter{
+				ID:       f.ID,
+				Category: f.Category,
+				IsRange:  f.XMLName.Local == "RangeFilter",
+				Fields:   make([]ScannerFilterField, 0, len(f.Fields)),
+			}
+			for _, fld := range f.Fields {
+				filter.Fields = append(filter.Fields, ScannerFilterField{
+					Code:        fld.Code,
+					DisplayName: fld.DisplayName,
+					Type:        fld.Type,
+				})
+			}
+			sp.Filters = append(sp.Filters, filter)
+		}
+	}
+
+	// Done
+	return &sp, nil
+}
+
+func newScannerLocations(tree xmlScanLocationTree) []ScannerLocation {
+	locations := make([]ScannerLocation, 0, len(tree.Locations))
+	for _, loc := range tree.Locations {
+		sl := ScannerLocation{
+			DisplayName:   loc.DisplayName,
+			LocationCode:  loc.LocationCode,
+			Instruments:   splitScannerList(loc.Instruments),
+			RouteExchange: loc.RouteExchange,
+		}
+		for _, subTree := range loc.LocationTrees {
+			sl.Locations = append(sl.Locations, newScannerLocations(subTree)...)
+		}
+		locations = append(locations, sl)
+	}
+	return locations
+}
+
+func splitScannerList(s string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if len(item) > 0 {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+func (sp *ScannerParameters) String() string {
+	return fmt.Sprintf(
+		"Instruments: %d, Locations: %d, ScanTypes: %d, Filters: %d",
+		len(sp.Instruments),
+		len(sp.Locations),
+		len(sp.ScanTypes),
+		len(sp.Filters),
+	)
+}
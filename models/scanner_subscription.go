@@ -0,0 +1,125 @@
+package models
+
+import (
+	"fmt"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+)
+
+// -----------------------------------------------------------------------------
+
+// ScannerSubscription defines a market scanner request. Instrument, LocationCode and ScanCode take the values
+// returned by RequestScannerParameters. Nil values are not sent.
+type ScannerSubscription struct {
+	NumberOfRows             *int32 // Defaults to the maximum allowed by the server (50)
+	Instrument               string // i.e.: STK
+	LocationCode             string // i.e.: STK.US.MAJOR
+	ScanCode                 string // i.e.: TOP_PERC_GAIN
+	AbovePrice               *float64
+	BelowPrice               *float64
+	AboveVolume              *int32
+	MarketCapAbove           *float64
+	MarketCapBelow           *float64
+	MoodyRatingAbove         string
+	MoodyRatingBelow         string
+	SpRatingAbove            string
+	SpRatingBelow            string
+	MaturityDateAbove        string
+	MaturityDateBelow        string
+	CouponRateAbove          *float64
+	CouponRateBelow          *float64
+	ExcludeConvertible       bool
+	AverageOptionVolumeAbove *int32
+	ScannerSettingPairs      string
+	StockTypeFilter          string // ALL, CORP or ADR
+}
+
+// ScannerResult is a single ranked contract of a scanner result set.
+type ScannerResult struct {
+	Rank            int32
+	ContractDetails *ContractDetails
+	Distance        string
+	Benchmark       string
+	Projection      string
+	LegsStr         string
+}
+
+// -----------------------------------------------------------------------------
+
+func NewScannerSubscription() *ScannerSubscription {
+	ss := ScannerSubscription{}
+	return &ss
+}
+
+func (ss *ScannerSubscription) EncodeMessage(_ int) ([]byte, error) {
+	msgEnc := message.NewRawEncoder()
+	if ss.NumberOfRows != nil {
+		msgEnc.Int32(*ss.NumberOfRows)
+	} else {
+		msgEnc.Int32(-1) // No row number specified
+	}
+	msgEnc.String(ss.Instrument)
+	msgEnc.String(ss.LocationCode)
+	msgEnc.String(ss.ScanCode)
+	msgEnc.FloatMax(ss.AbovePrice)
+	msgEnc.FloatMax(ss.BelowPrice)
+	msgEnc.Int32Max(ss.AboveVolume)
+	msgEnc.FloatMax(ss.MarketCapAbove)
+	msgEnc.FloatMax(ss.MarketCapBelow)
+	msgEnc.String(ss.MoodyRatingAbove)
+	msgEnc.String(ss.MoodyRatingBelow)
+	msgEnc.String(ss.SpRatingAbove)
+	msgEnc.String(ss.SpRatingBelow)
+	msgEnc.String(ss.MaturityDateAbove)
+	msgEnc.String(ss.MaturityDateBelow)
+	msgEnc.FloatMax(ss.CouponRateAbove)
+	msgEnc.FloatMax(ss.CouponRateBelow)
+	msgEnc.Bool(ss.ExcludeConvertible)
+	msgEnc.Int32Max(ss.AverageOptionVolumeAbove)
+	msgEnc.String(ss.ScannerSettingPairs)
+	msgEnc.String(ss.StockTypeFilter)
+	return msgEnc.Bytes(), msgEnc.Err()
+}
+
+func (ss *ScannerSubscription) String() string {
+	return fmt.Sprintf(
+		"Instrument: %s, LocationCode: %s, ScanCode: %s",
+		ss.Instrument, ss.LocationCode, ss.ScanCode,
+	)
+}
+
+func NewScannerResultFromMessageDecoder(msgDec *message.Decoder) *ScannerResult {
+	sr := ScannerResult{
+		ContractDetails: NewContractDetails(),
+	}
+	sr.Rank = msgDec.Int32()
+	sr.ContractDetails.Contract.ConID = msgDec.Int32()
+	sr.ContractDetails.Contract.Symbol = msgDec.String()
+	sr.ContractDetails.Contract.SecType = NewSecurityTypeFromString(msgDec.String())
+	sr.ContractDetails.Contract.LastTradeDateOrContractMonth = msgDec.String()
+	sr.ContractDetails.Contract.Strike = msgDec.FloatMax()
+	sr.ContractDetails.Contract.Right = msgDec.String()
+	sr.ContractDetails.Contract.Exchange = msgDec.String()
+	sr.ContractDetails.Contract.Currency = msgDec.String()
+	sr.ContractDetails.Contract.LocalSymbol = msgDec.String()
+	sr.ContractDetails.MarketName = msgDec.String()
+	sr.ContractDetails.Contract.TradingClass = msgDec.String()
+	sr.Distance = msgDec.String()
+	sr.Benchmark = msgDec.String()
+	sr.Projection = msgDec.String()
+	sr.LegsStr = msgDec.String()
+	return &sr
+}
+
+func (sr *ScannerResult) String() string {
+	return fmt.Sprintf(
+		"Rank: %d, Contract: [%s], MarketName: %s, Distance: %s, Benchmark: %s, Projection: %s, Legs: %s",
+		sr.Rank,
+		sr.ContractDetails.Contract,
+		sr.ContractDetails.MarketName,
+		sr.Distance,
+		sr.Benchmark,
+		sr.Projection,
+		sr.LegsStr,
+	)
+}
@@ -2,6 +2,8 @@ package models
 
 import (
 	"fmt"
+
+	"github.com/mxmauro/ibkr/utils/encoders/message"
 )
 
 // -----------------------------------------------------------------------------
@@ -18,6 +20,14 @@ func NewSmartComponent() SmartComponent {
 	return SmartComponent{}
 }
 
+func NewSmartComponentFromMessageDecoder(msgDec *message.Decoder) SmartComponent {
+	sc := NewSmartComponent()
+	sc.BitNumber = msgDec.Int64()
+	sc.Exchange = msgDec.String()
+	sc.ExchangeLetter = msgDec.String()
+	return sc
+}
+
 func (sc *SmartComponent) String() string {
 	return fmt.Sprintf(
 		"BitNumber: %d, Exchange: %s, ExchangeLetter: %s",
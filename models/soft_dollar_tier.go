@@ -2,6 +2,10 @@ package models
 
 import (
 	"fmt"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
 )
 
 // -----------------------------------------------------------------------------
@@ -19,6 +23,34 @@ func NewSoftDollarTier() SoftDollarTier {
 	return SoftDollarTier{}
 }
 
+func NewSoftDollarTierFromMessageDecoder(msgDec *message.Decoder) SoftDollarTier {
+	sdt := NewSoftDollarTier()
+	sdt.Name = msgDec.String()
+	sdt.Value = msgDec.String()
+	sdt.DisplayName = msgDec.String()
+	return sdt
+}
+
+func NewSoftDollarTierFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.SoftDollarTier) SoftDollarTier {
+	sdt := NewSoftDollarTier()
+	if pb == nil {
+		return sdt
+	}
+	sdt.Name = msgDec.String(pb.Name)
+	sdt.Value = msgDec.String(pb.Value)
+	sdt.DisplayName = msgDec.String(pb.DisplayName)
+	return sdt
+}
+
+func (sdt SoftDollarTier) Proto() *protobuf.SoftDollarTier {
+	pb := protobuf.SoftDollarTier{
+		Name:        protofmt.String(sdt.Name),
+		Value:       protofmt.String(sdt.Value),
+		DisplayName: protofmt.String(sdt.DisplayName),
+	}
+	return &pb
+}
+
 func (sdt SoftDollarTier) String() string {
 	return fmt.Sprintf("Name: %s, Value: %s, DisplayName: %s",
 		sdt.Name,
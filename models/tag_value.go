@@ -27,6 +27,28 @@ func (tv TagValue) String() string {
 	return fmt.Sprintf("%s=%s", tv.Tag, tv.Value)
 }
 
+func newTagValueListFromProtobuf(m map[string]string) []TagValue {
+	tvl := make([]TagValue, 0, len(m))
+	for k, v := range m {
+		tvl = append(tvl, TagValue{
+			Tag:   k,
+			Value: v,
+		})
+	}
+	return tvl
+}
+
+func tagValueListProto(tvl []TagValue) map[string]string {
+	if len(tvl) == 0 {
+		return nil
+	}
+	m := make(map[string]string, len(tvl))
+	for _, tv := range tvl {
+		m[tv.Tag] = tv.Value
+	}
+	return m
+}
+
 func (tvl *TagValueList) EncodeMessage(_ int) ([]byte, error) {
 	msgEnc := message.NewRawEncoder()
 	for _, tv := range *tvl {
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/mxmauro/ibkr/proto/protobuf"
+	"github.com/mxmauro/ibkr/utils/encoders/message"
+	"github.com/mxmauro/ibkr/utils/encoders/protofmt"
+)
+
+// -----------------------------------------------------------------------------
+
+type TickByTickType int32
+
+const (
+	TickByTickTypeLast     TickByTickType = 1
+	TickByTickTypeAllLast  TickByTickType = 2
+	TickByTickTypeBidAsk   TickByTickType = 3
+	TickByTickTypeMidPoint TickByTickType = 4
+)
+
+// TickByTick is a tick received for a tick-by-tick subscription. It can be a *TickByTickLast, a *TickByTickBidAsk or
+// a *TickByTickMidPoint.
+type TickByTick interface {
+	TickType() TickByTickType
+	String() string
+}
+
+// TickByTickLast is a trade tick. Received for Last and AllLast subscriptions.
+type TickByTickLast struct {
+	tickType          TickByTickType
+	Time              time.Time
+	Price             float64
+	Size              *Decimal
+	TickAttribLast    TickAttribLast
+	Exchange          string
+	SpecialConditions string
+}
+
+// TickByTickBidAsk is a bid/ask quote tick.
+type TickByTickBidAsk struct {
+	Time             time.Time
+	BidPrice         float64
+	AskPrice         float64
+	BidSize          *Decimal
+	AskSize          *Decimal
+	TickAttribBidAsk TickAttribBidAsk
+}
+
+// TickByTickMidPoint is a midpoint tick.
+type TickByTickMidPoint struct {
+	Time     time.Time
+	MidPoint float64
+}
+
+// -----------------------------------------------------------------------------
+
+func (tt TickByTickType) String() string {
+	switch tt {
+	case TickByTickTypeLast:
+		return "Last"
+	case TickByTickTypeAllLast:
+		return "AllLast"
+	case TickByTickTypeBidAsk:
+		return "BidAsk"
+	case TickByTickTypeMidPoint:
+		return "MidPoint"
+	}
+	return ""
+}
+
+// NewTickByTickFromMessageDecoder decodes a tick-by-tick tick. The request ID must be already consumed from the
+// decoder. It returns nil if the tick type is none or unknown.
+func NewTickByTickFromMessageDecoder(msgDec *message.Decoder) TickByTick {
+	tickType := TickByTickType(msgDec.Int32())
+	ts := msgDec.EpochTimestamp(false)
+
+	switch tickType {
+	case TickByTickTypeLast, TickByTickTypeAllLast:
+		tbt := TickByTickLast{
+			tickType: tickType,
+			Time:     ts,
+		}
+		tbt.Price = msgDec.Float()
+		tbt.Size = NewDecimalMaxFromMessageDecoder(msgDec)
+		mask := msgDec.Int32()
+		tbt.TickAttribLast.PastLimit = mask&1 != 0
+		tbt.TickAttribLast.Unreported = mask&2 != 0
+		tbt.Exchange = msgDec.String()
+		tbt.SpecialConditions = msgDec.String()
+		return &tbt
+
+	case TickByTickTypeBidAsk:
+		tbt := TickByTickBidAsk{
+			Time: ts,
+		}
+		tbt.BidPrice = msgDec.Float()
+		tbt.AskPrice = msgDec.Float()
+		tbt.BidSize = NewDecimalMaxFromMessageDecoder(msgDec)
+		tbt.AskSize = NewDecimalMaxFromMessageDecoder(msgDec)
+		mask := msgDec.Int32()
+		tbt.TickAttribBidAsk.BidPastLow = mask&1 != 0
+		tbt.TickAttribBidAsk.AskPastHigh = mask&2 != 0
+		return &tbt
+
+	case TickByTickTypeMidPoint:
+		tbt := TickByTickMidPoint{
+			Time: ts,
+		}
+		tbt.MidPoint = msgDec.Float()
+		return &tbt
+	}
+	return nil
+}
+
+// NewTickByTickFromProtobufDecoder decodes a tick-by-tick tick. It returns nil if the tick type is none or unknown.
+func NewTickByTickFromProtobufDecoder(msgDec *protofmt.Decoder, pb *protobuf.TickByTickData) TickByTick {
+	if pb == nil {
+		return nil
+	}
+	tickType := TickByTickType(msgDec.Int32(pb.TickType))
+
+	switch tick := pb.Tick.(type) {
+	case *protobuf.TickByTickData_HistoricalTickLast:
+		if tickType != TickByTickTypeLast && tickType != TickByTickTypeAllLast {
+			return nil
+		}
+		htl := NewHistoricalTickLastFromProtobufDecoder(msgDec, tick.HistoricalTickLast)
+		return &TickByTickLast{
+			tickType:          tickType,
+			Time:              htl.Time,
+			Price:             htl.Price,
+			Size:              htl.Size,
+			TickAttribLast:    htl.TickAttribLast,
+			Exchange:          htl.Exchange,
+			SpecialConditions: htl.SpecialConditions,
+		}
+
+	case *protobuf.TickByTickData_HistoricalTickBidAsk:
+		htba := NewHistoricalTickBidAskFromProtobufDecoder(msgDec, tick.HistoricalTickBidAsk)
+		return &TickByTickBidAsk{
+			Time:             htba.Time,
+			BidPrice:         htba.PriceBid,
+			AskPrice:         htba.PriceAsk,
+			BidSize:          htba.SizeBid,
+			AskSize:          htba.SizeAsk,
+			TickAttribBidAsk: htba.TickAttribBidAsk,
+		}
+
+	case *protobuf.TickByTickData_HistoricalTickMidPoint:
+		ht := NewHistoricalTickFromProtobufDecoder(msgDec, tick.HistoricalTickMidPoint)
+		return &TickByTickMidPoint{
+			Time:     ht.Time,
+			MidPoint: ht.Price,
+		}
+	}
+	return nil
+}
+
+func (t *TickByTickLast) TickType() TickByTickType {
+	return t.tickType
+}
+
+func (t *TickByTickLast) String() string {
+	return fmt.Sprintf(
+		"Type: %s, Time: %s, Price: %f, Size: %s, Exchange: %s, SpecialConditions: %s, TickAttribLast [%s]",
+		t.tickType.String(),
+		t.Time.Format("2006/01/02 15:04:05"),
+		t.Price,
+		t.Size.StringMax(),
+		t.Exchange,
+		t.SpecialConditions,
+		t.TickAttribLast.String(),
+	)
+}
+
+func (t *TickByTickBidAsk) TickType() TickByTickType {
+	return TickByTickTypeBidAsk
+}
+
+func (t *TickByTickBidAsk) String() string {
+	return fmt.Sprintf(
+		"Type: %s, Time: %s, BidPrice: %f, AskPrice: %f, BidSize: %s, AskSize: %s, TickAttribBidAsk [%s]",
+		TickByTickTypeBidAsk.String(),
+		t.Time.Format("2006/01/02 15:04:05"),
+		t.BidPrice,
+		t.AskPrice,
+		t.BidSize.StringMax(),
+		t.AskSize.StringMax(),
+		t.TickAttribBidAsk.String(),
+	)
+}
+
+func (t *TickByTickMidPoint) TickType() TickByTickType {
+	return TickByTickTypeMidPoint
+}
+
+func (t *TickByTickMidPoint) String() string {
+	return fmt.Sprintf(
+		"Type: %s, Time: %s, MidPoint: %f",
+		TickByTickTypeMidPoint.String(),
+		t.Time.Format("2006/01/02 15:04:05"),
+		t.MidPoint,
+	)
+}
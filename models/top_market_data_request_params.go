@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+
+	"github.com/mxmauro/ibkr/utils/formatter"
+)
+
+// -----------------------------------------------------------------------------
+
+// TopMarketDataRequestParams is sent once after market data is requested. BBOExchange can be used with
+// RequestSmartComponents to map the exchange letters received in SMART market depth data.
+type TopMarketDataRequestParams struct {
+	MinTick             *float64
+	BBOExchange         string
+	SnapshotPermissions int32
+}
+
+// -----------------------------------------------------------------------------
+
+func NewTopMarketDataRequestParams() *TopMarketDataRequestParams {
+	return &TopMarketDataRequestParams{}
+}
+
+func (t *TopMarketDataRequestParams) String() string {
+	sb := strings.Builder{}
+	_, _ = sb.WriteString("Type=RequestParams, MinTick=")
+	_, _ = sb.WriteString(formatter.FloatMaxString(t.MinTick))
+	_, _ = sb.WriteString(", BBOExchange=\"")
+	_, _ = sb.WriteString(t.BBOExchange)
+	_, _ = sb.WriteString("\", SnapshotPermissions=")
+	_, _ = sb.WriteString(formatter.Int32String(t.SnapshotPermissions))
+	return sb.String()
+}
@@ -0,0 +1,91 @@
+package ibkr
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/mxmauro/ibkr/models"
+)
+
+// -----------------------------------------------------------------------------
+
+// OrderIDStore is an optional persistence hook used to keep track of the order IDs already handed out so a restarted
+// process never reuses an order ID the server has already seen.
+type OrderIDStore interface {
+	// Load returns the last order ID used or zero if none.
+	Load() (models.OrderID, error)
+	// Save stores the last order ID used.
+	Save(orderID models.OrderID) error
+}
+
+type orderIDAllocator struct {
+	mtx    sync.Mutex
+	nextID models.OrderID
+	store  OrderIDStore
+}
+
+// -----------------------------------------------------------------------------
+
+var errOrderIDNotAvailable = errors.New("next valid order id not available")
+
+// -----------------------------------------------------------------------------
+
+func (c *Client) initOrderIDAllocator(store OrderIDStore) error {
+	c.orderIDs = orderIDAllocator{
+		mtx:   sync.Mutex{},
+		store: store,
+	}
+
+	// Load the last persisted order ID
+	if store != nil {
+		lastID, err := store.Load()
+		if err != nil {
+			return err
+		}
+		if lastID > 0 {
+			c.orderIDs.nextID = lastID + 1
+		}
+	}
+
+	// Done
+	return nil
+}
+
+// seed updates the next order ID to use with the one sent by the server. Order IDs never go backwards.
+func (a *orderIDAllocator) seed(orderID models.OrderID) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if orderID > a.nextID {
+		a.nextID = orderID
+	}
+}
+
+func (a *orderIDAllocator) peek() models.OrderID {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	return a.nextID
+}
+
+func (a *orderIDAllocator) next() (models.OrderID, error) {
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.nextID <= 0 {
+		return 0, errOrderIDNotAvailable
+	}
+	orderID := a.nextID
+
+	// Persist before handing it out
+	if a.store != nil {
+		err := a.store.Save(orderID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	// Done
+	a.nextID += 1
+	return orderID, nil
+}
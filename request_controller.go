@@ -7,6 +7,8 @@ import (
 	"net"
 	"sync"
 	"sync/atomic"
+
+	"github.com/mxmauro/ibkr/models"
 )
 
 // -----------------------------------------------------------------------------
@@ -15,6 +17,7 @@ type RequestManager struct {
 	mtx           sync.Mutex
 	reqsWithID    map[int32]*Request
 	reqsWithoutID map[int]*NonIdRequestList
+	orders        map[models.OrderID]*Request
 }
 
 type Request struct {
@@ -32,6 +35,7 @@ type Request struct {
 type RequestOptions struct {
 	Type       RequestType
 	MsgCode    int
+	OrderID    models.OrderID // Only used by RequestTypeOrder requests
 	Response   interface{}
 	CompleteCB RequestCompleteCallback
 }
@@ -52,8 +56,13 @@ const (
 	RequestTypeRequestWithID RequestType = iota
 	RequestTypeRequestWithoutID
 	RequestTypeRequestWithTickerID
+	RequestTypeOrder
 )
 
+// Request and ticker IDs are allocated from a range far above the order IDs handed out by the server, so an
+// error message carrying an ID can never be confused between them.
+const firstRequestID int32 = 0x40000000
+
 // -----------------------------------------------------------------------------
 
 func (c *Client) initRequestManager() {
@@ -61,13 +70,21 @@ func (c *Client) initRequestManager() {
 		mtx:           sync.Mutex{},
 		reqsWithID:    make(map[int32]*Request),
 		reqsWithoutID: make(map[int]*NonIdRequestList),
+		orders:        make(map[models.OrderID]*Request),
 	}
 }
 
 func (c *Client) createRequest(opts RequestOptions) *Request {
+	var id int32
+
+	if opts.Type == RequestTypeOrder {
+		id = int32(opts.OrderID)
+	} else {
+		id = c.getNextRequestID(opts.Type)
+	}
 	req := &Request{
 		_type:       opts.Type,
-		id:          c.getNextRequestID(opts.Type),
+		id:          id,
 		msgCode:     opts.MsgCode,
 		completeCB:  opts.CompleteCB,
 		responseMtx: sync.Mutex{},
@@ -82,15 +99,18 @@ func (c *Client) createRequest(opts RequestOptions) *Request {
 func (c *Client) getNextRequestID(_type RequestType) int32 {
 	var nextReqID int32
 	var addr *int32
+	var minReqID int32
 
 	switch _type {
 	case RequestTypeRequestWithID:
 		fallthrough
 	case RequestTypeRequestWithTickerID:
 		addr = &c.nextValidReqID
+		minReqID = firstRequestID
 
 	case RequestTypeRequestWithoutID:
 		addr = &c.nextValidReqWithoutID
+		minReqID = 1
 	}
 
 	for {
@@ -101,7 +121,7 @@ func (c *Client) getNextRequestID(_type RequestType) int32 {
 		if currentReqID != math.MaxInt32 {
 			nextReqID = currentReqID + 1
 		} else {
-			nextReqID = 1
+			nextReqID = minReqID
 		}
 		if atomic.CompareAndSwapInt32(addr, currentReqID, nextReqID) {
 			return currentReqID
@@ -136,6 +156,9 @@ func (rm *RequestManager) addRequest(req *Request) {
 	case RequestTypeRequestWithTickerID:
 		rm.reqsWithID[req.id] = req
 
+	case RequestTypeOrder:
+		rm.orders[models.OrderID(req.id)] = req
+
 	case RequestTypeRequestWithoutID:
 		var l *NonIdRequestList
 		var ok bool
@@ -158,6 +181,9 @@ func (rm *RequestManager) removeRequest(req *Request, err error) {
 	case RequestTypeRequestWithTickerID:
 		delete(rm.reqsWithID, req.id)
 
+	case RequestTypeOrder:
+		delete(rm.orders, models.OrderID(req.id))
+
 	case RequestTypeRequestWithoutID:
 		if l, ok := rm.reqsWithoutID[req.msgCode]; ok {
 			l.removeRequest(req.id)
@@ -173,13 +199,18 @@ func (rm *RequestManager) removeAndTryCancelAllRequests(err error) {
 	rm.mtx.Lock()
 	oldReqsWithID := rm.reqsWithID
 	oldReqsWithoutID := rm.reqsWithoutID
+	oldOrders := rm.orders
 	rm.reqsWithID = make(map[int32]*Request)
 	rm.reqsWithoutID = make(map[int]*NonIdRequestList)
+	rm.orders = make(map[models.OrderID]*Request)
 	rm.mtx.Unlock()
 
 	for _, req := range oldReqsWithID {
 		req.complete(err)
 	}
+	for _, req := range oldOrders {
+		req.complete(err)
+	}
 	for _, l := range oldReqsWithoutID {
 		for elem := l.List.Front(); elem != nil; elem = elem.Next() {
 			req := elem.Value.(*Request)
@@ -214,6 +245,39 @@ func (rm *RequestManager) withRequestWithID(reqID int32, cb WithRequestWithIdCal
 	}
 }
 
+// withOrder executes the callback on the tracked order with the given ID and returns false if no order was found.
+func (rm *RequestManager) withOrder(orderID models.OrderID, cb WithRequestWithIdCallback) bool {
+	rm.mtx.Lock()
+	req, ok := rm.orders[orderID]
+	rm.mtx.Unlock()
+
+	if !ok {
+		return false
+	}
+	if req.Err() != nil {
+		return true
+	}
+
+	req.responseMtx.Lock()
+	if req.response == nil {
+		req.responseMtx.Unlock()
+		return true
+	}
+	done, err := cb(req.response)
+	req.responseMtx.Unlock()
+
+	if done || err != nil {
+		rm.mtx.Lock()
+		delete(rm.orders, orderID)
+		rm.mtx.Unlock()
+
+		req.complete(err)
+	}
+
+	// Done
+	return true
+}
+
 func (rm *RequestManager) withRequestWithoutID(msgCode int, cb WithRequestWithoutIdCallback) {
 	var req *Request
 
@@ -238,6 +302,41 @@ func (rm *RequestManager) withRequestWithoutID(msgCode int, cb WithRequestWithou
 	req.complete(err)
 }
 
+// withActiveRequestWithoutID executes the callback on the first queued request without ID. Unlike
+// withRequestWithoutID, the request is kept in the queue until the callback reports it is done, so it can be used
+// for responses spanning several messages.
+func (rm *RequestManager) withActiveRequestWithoutID(msgCode int, cb WithRequestWithIdCallback) {
+	var req *Request
+
+	rm.mtx.Lock()
+	if l, ok := rm.reqsWithoutID[msgCode]; ok {
+		req = l.firstRequest()
+	}
+	rm.mtx.Unlock()
+
+	if req == nil || req.Err() != nil {
+		return
+	}
+
+	req.responseMtx.Lock()
+	if req.response == nil {
+		req.responseMtx.Unlock()
+		return
+	}
+	done, err := cb(req.response)
+	req.responseMtx.Unlock()
+
+	if done || err != nil {
+		rm.mtx.Lock()
+		if l, ok := rm.reqsWithoutID[msgCode]; ok {
+			l.removeRequest(req.id)
+		}
+		rm.mtx.Unlock()
+
+		req.complete(err)
+	}
+}
+
 func (req *Request) Type() RequestType {
 	return req._type
 }
@@ -254,6 +353,10 @@ func (req *Request) Err() error {
 	return v.(error)
 }
 
+func (req *Request) IsCompleted() bool {
+	return atomic.LoadInt32(&req.done) != 0
+}
+
 func (req *Request) CompleteCh() <-chan struct{} {
 	return req.completedCh
 }
@@ -289,6 +392,14 @@ func (nirl *NonIdRequestList) popFirstRequest() *Request {
 	return elem.Value.(*Request)
 }
 
+func (nirl *NonIdRequestList) firstRequest() *Request {
+	elem := nirl.List.Front()
+	if elem == nil {
+		return nil
+	}
+	return elem.Value.(*Request)
+}
+
 func (nirl *NonIdRequestList) removeRequest(id int32) {
 	for elem := nirl.List.Front(); elem != nil; elem = elem.Next() {
 		req := elem.Value.(*Request)